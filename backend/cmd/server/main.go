@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log"
 
 	"github.com/gin-gonic/gin"
@@ -10,12 +11,14 @@ import (
 	"github.com/hiresense/backend/internal/jobs"
 	"github.com/hiresense/backend/internal/middleware"
 	"github.com/hiresense/backend/internal/scraper"
+	"github.com/hiresense/backend/internal/skills"
 	"github.com/hiresense/backend/internal/users"
 )
 
 func main() {
 	// Load configuration
 	config.Load()
+	jobs.SetFXRates(config.AppConfig.FXRates)
 
 	// Set Gin mode
 	if config.AppConfig.Environment == "production" {
@@ -39,6 +42,7 @@ func main() {
 	jobsHandler := jobs.NewHandler()
 	aiHandler := ai.NewHandler()
 	scraperHandler := scraper.NewHandler()
+	skillsHandler := skills.NewHandler()
 
 	// Auth routes (public + protected)
 	authGroup := r.Group("/auth")
@@ -66,6 +70,12 @@ func main() {
 	adminGroup := r.Group("/admin")
 	adminGroup.Use(authMiddleware, middleware.AdminMiddleware())
 	scraperHandler.RegisterRoutes(adminGroup)
+	skillsHandler.RegisterRoutes(adminGroup)
+
+	// Built-in scrape scheduler
+	if config.AppConfig.SchedulerEnabled {
+		go scraperHandler.RunScheduler(context.Background())
+	}
 
 	// Start server
 	addr := ":" + config.AppConfig.Port
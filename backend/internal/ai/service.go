@@ -4,9 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log"
+	"strings"
 
 	"github.com/hiresense/backend/internal/config"
 	"github.com/hiresense/backend/internal/jobs"
+	"github.com/hiresense/backend/internal/skills"
 	"github.com/hiresense/backend/internal/users"
 	"github.com/sashabaranov/go-openai"
 )
@@ -44,11 +47,19 @@ type MarketDemandItem struct {
 }
 
 func (s *Service) GetRecommendations(ctx context.Context, user *users.User) ([]RecommendationResult, error) {
-	// Get recent jobs
-	jobsResponse, err := s.jobsRepo.FindAll(ctx, &jobs.JobFilter{
-		Limit: 50,
-		Page:  1,
-	})
+	// Get recent jobs the user can work from where they are
+	filter := &jobs.JobFilter{
+		Limit:    50,
+		Page:     1,
+		Remote:   user.Profile.RemotePreference,
+		Country:  user.Profile.Country,
+		Timezone: user.Profile.Timezone,
+	}
+	if _, err := filter.LocationPreference(); err != nil {
+		log.Printf("⚠️ Ignoring location preference of user %s: %v", user.ID.Hex(), err)
+		filter.Remote, filter.Country, filter.Timezone = "", "", ""
+	}
+	jobsResponse, err := s.jobsRepo.FindAll(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -64,10 +75,15 @@ func (s *Service) GetRecommendations(ctx context.Context, user *users.User) ([]R
 		hiddenIDMap[id.Hex()] = true
 	}
 
-	// Filter out hidden jobs
+	// Filter out hidden jobs, including roles hidden through another
+	// source's posting
 	var filteredJobs []jobs.Job
 	for _, job := range jobsResponse.Jobs {
-		if !hiddenIDMap[job.ID.Hex()] {
+		hidden := hiddenIDMap[job.ID.Hex()]
+		for _, alt := range job.Alternates {
+			hidden = hidden || hiddenIDMap[alt.JobID.Hex()]
+		}
+		if !hidden {
 			filteredJobs = append(filteredJobs, job)
 		}
 	}
@@ -183,8 +199,44 @@ Respond with JSON:
 	return &analysis, nil
 }
 
+// promptDescriptionLength caps how much of each description goes into
+// the recommendation prompt.
+const promptDescriptionLength = 600
+
+type promptJob struct {
+	Title          string   `json:"title"`
+	Company        string   `json:"company"`
+	Location       string   `json:"location"`
+	Salary         string   `json:"salary,omitempty"`
+	Seniority      string   `json:"seniority,omitempty"`
+	EmploymentType string   `json:"employmentType,omitempty"`
+	Skills         []string `json:"skills"`
+	Description    string   `json:"description"`
+}
+
 func buildRecommendationPrompt(profile users.Profile, filteredJobs []jobs.Job) string {
-	jobsJSON, _ := json.Marshal(filteredJobs)
+	// Send plain text rather than the stored HTML to keep the prompt small
+	compact := make([]promptJob, len(filteredJobs))
+	for i, job := range filteredJobs {
+		description := job.DescriptionText
+		if description == "" {
+			description = job.Description
+		}
+		if runes := []rune(description); len(runes) > promptDescriptionLength {
+			description = string(runes[:promptDescriptionLength]) + "…"
+		}
+		compact[i] = promptJob{
+			Title:          job.Title,
+			Company:        job.Company,
+			Location:       job.Location,
+			Salary:         job.Salary,
+			Seniority:      job.Seniority,
+			EmploymentType: job.EmploymentType,
+			Skills:         job.Skills,
+			Description:    description,
+		}
+	}
+	jobsJSON, _ := json.Marshal(compact)
 	return fmt.Sprintf(`Match these jobs to the user profile and score them:
 
 User Profile:
@@ -199,21 +251,32 @@ Jobs (indexed from 0):
 Return a JSON array with top 10 matches:
 [{"jobIndex": 0, "score": 85, "matchReason": "Your React expertise matches...", "skillMatch": ["React", "TypeScript"], "skillGaps": ["AWS"]}]
 
-Score 0-100. Focus on skill overlap and experience fit.`,
+Score 0-100. Focus on skill overlap and experience fit: compare each job's seniority with the user's experience level.`,
 		profile.Skills, profile.ExperienceLevel, profile.PreferredRoles,
 		profile.SalaryRange.Min, profile.SalaryRange.Max, string(jobsJSON))
 }
 
+// seniorityGapPenalty is taken off a job's score for each step between its
+// seniority and the user's experience level.
+const seniorityGapPenalty = 15
+
 func simpleMatch(profile users.Profile, filteredJobs []jobs.Job) []RecommendationResult {
 	results := make([]RecommendationResult, 0, len(filteredJobs))
 
 	for _, job := range filteredJobs {
 		score, matched := calculateSkillMatch(profile.Skills, job.Skills)
 		if score > 0 {
+			reason := fmt.Sprintf("Matches %d of your skills", len(matched))
+			if gap, ok := jobs.SeniorityGap(profile.ExperienceLevel, job.Seniority); ok {
+				score = max(score-gap*seniorityGapPenalty, 0)
+				if gap == 0 {
+					reason += " at your experience level"
+				}
+			}
 			results = append(results, RecommendationResult{
 				Job:         job,
 				Score:       score,
-				MatchReason: fmt.Sprintf("Matches %d of your skills", len(matched)),
+				MatchReason: reason,
 				SkillMatch:  matched,
 				SkillGaps:   []string{},
 			})
@@ -223,15 +286,19 @@ func simpleMatch(profile users.Profile, filteredJobs []jobs.Job) []Recommendatio
 	return results
 }
 
+// calculateSkillMatch scores how many of a job's skills the user has.
+// Both sides are normalized through the skill taxonomy and compared
+// case-insensitively, so "Go" matches "golang".
 func calculateSkillMatch(userSkills, jobSkills []string) (int, []string) {
 	skillSet := make(map[string]bool)
-	for _, s := range userSkills {
-		skillSet[s] = true
+	for _, s := range skills.NormalizeAll(userSkills) {
+		skillSet[strings.ToLower(s)] = true
 	}
 
 	var matched []string
+	jobSkills = skills.NormalizeAll(jobSkills)
 	for _, s := range jobSkills {
-		if skillSet[s] {
+		if skillSet[strings.ToLower(s)] {
 			matched = append(matched, s)
 		}
 	}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"log"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -12,12 +14,52 @@ import (
 )
 
 type Config struct {
-	Port           string
-	MongoURI       string
-	JWTSecret      string
-	OpenAIKey      string
-	FrontendURL    string
-	Environment    string
+	Port        string
+	MongoURI    string
+	JWTSecret   string
+	OpenAIKey   string
+	FrontendURL string
+	Environment string
+
+	// Company boards to scrape
+	LeverCompanies   []string
+	GreenhouseBoards []string
+
+	// Career pages embedding schema.org JobPosting blocks
+	JobPostingURLs []string
+
+	// "Who is hiring?" threads; the latest one when empty
+	HNThreadIDs []string
+
+	// Directory of declarative scraper definitions
+	ScraperDefinitionsDir string
+
+	// Scrape run limits
+	ScrapeConcurrency int
+	ScrapeTimeout     time.Duration
+
+	// HTTP politeness: retries of failed requests and the minimum gap
+	// between requests to the same host
+	ScrapeRetries      int
+	ScrapeHostInterval time.Duration
+
+	// A source's circuit opens after this many consecutive failed runs and
+	// half-opens after the cooldown
+	CircuitFailureThreshold int
+	CircuitCooldown         time.Duration
+
+	// Built-in scrape scheduler
+	SchedulerEnabled bool
+	ScrapeCron       string
+	ScrapeJitter     time.Duration
+
+	// Jobs are expired after missing this many successful runs of their
+	// source, or after going unseen for JobExpiryMaxAge
+	JobExpiryMissedRuns int
+	JobExpiryMaxAge     time.Duration
+
+	// USD conversion rate overrides for salary normalisation
+	FXRates map[string]float64
 }
 
 var (
@@ -36,6 +78,31 @@ func Load() {
 		OpenAIKey:   getEnv("OPENAI_API_KEY", ""),
 		FrontendURL: getEnv("FRONTEND_URL", "http://localhost:5173"),
 		Environment: getEnv("ENVIRONMENT", "development"),
+
+		LeverCompanies:   getEnvList("LEVER_COMPANIES"),
+		GreenhouseBoards: getEnvList("GREENHOUSE_BOARDS"),
+		JobPostingURLs:   getEnvList("JOBPOSTING_URLS"),
+		HNThreadIDs:      getEnvList("HN_THREAD_IDS"),
+
+		ScraperDefinitionsDir: getEnv("SCRAPER_DEFINITIONS_DIR", "scrapers"),
+
+		ScrapeConcurrency: getEnvInt("SCRAPE_CONCURRENCY", 4),
+		ScrapeTimeout:     getEnvDuration("SCRAPE_TIMEOUT", 5*time.Minute),
+
+		ScrapeRetries:      getEnvInt("SCRAPE_RETRIES", 3),
+		ScrapeHostInterval: getEnvDuration("SCRAPE_HOST_INTERVAL", time.Second),
+
+		CircuitFailureThreshold: getEnvInt("CIRCUIT_FAILURE_THRESHOLD", 3),
+		CircuitCooldown:         getEnvDuration("CIRCUIT_COOLDOWN", 6*time.Hour),
+
+		SchedulerEnabled: getEnvBool("SCHEDULER_ENABLED", true),
+		ScrapeCron:       getEnv("SCRAPE_CRON", "0 6 * * *"),
+		ScrapeJitter:     getEnvDuration("SCRAPE_JITTER", 10*time.Minute),
+
+		JobExpiryMissedRuns: getEnvInt("JOB_EXPIRY_MISSED_RUNS", 3),
+		JobExpiryMaxAge:     time.Duration(getEnvInt("JOB_EXPIRY_DAYS", 30)) * 24 * time.Hour,
+
+		FXRates: getEnvRates("FX_RATES"),
 	}
 
 	// Connect to MongoDB
@@ -49,6 +116,53 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+func getEnvInt(key string, defaultValue int) int {
+	if value, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvBool(key string, defaultValue bool) bool {
+	if value, err := strconv.ParseBool(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value, err := time.ParseDuration(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+// getEnvList reads a comma-separated list, dropping empty entries.
+func getEnvList(key string) []string {
+	var result []string
+	for _, part := range strings.Split(os.Getenv(key), ",") {
+		if trimmed := strings.TrimSpace(part); trimmed != "" {
+			result = append(result, trimmed)
+		}
+	}
+	return result
+}
+
+// getEnvRates reads comma-separated CODE=rate pairs, e.g. "EUR=1.08,GBP=1.27".
+func getEnvRates(key string) map[string]float64 {
+	rates := make(map[string]float64)
+	for _, pair := range getEnvList(key) {
+		code, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+		if rate, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+			rates[strings.TrimSpace(code)] = rate
+		}
+	}
+	return rates
+}
+
 func connectMongoDB() {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -0,0 +1,209 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// bulkBatchSize is how many jobs go into one lookup and one bulk write.
+const bulkBatchSize = 500
+
+// Store is the persistence the scrapers need. Repository is backed by
+// MongoDB; MemoryRepository keeps jobs in memory for tests and benchmarks.
+type Store interface {
+	BulkUpsert(ctx context.Context, jobs []Job) (*BulkResult, error)
+	ExpireMissing(ctx context.Context, source string, runStartedAt time.Time, maxMissedRuns int, maxAge time.Duration) (int64, error)
+	ExpirePastValidThrough(ctx context.Context, source string) (int64, error)
+	ClusterDuplicates(ctx context.Context, since time.Time) (int, error)
+}
+
+// BulkResult counts what a BulkUpsert did with each job. Unchanged jobs
+// were already stored with the same content hash and were only marked as
+// seen.
+type BulkResult struct {
+	Added     int         `json:"added"`
+	Updated   int         `json:"updated"`
+	Unchanged int         `json:"unchanged"`
+	Failed    int         `json:"failed"`
+	Errors    []ItemError `json:"errors"`
+}
+
+// ItemError is the reason a single job could not be stored.
+type ItemError struct {
+	Source   string `json:"source"`
+	SourceID string `json:"sourceId"`
+	Reason   string `json:"reason"`
+}
+
+func (e ItemError) Error() string {
+	return fmt.Sprintf("%s/%s: %s", e.Source, e.SourceID, e.Reason)
+}
+
+type jobKey struct {
+	source   string
+	sourceID string
+}
+
+type upsertKind int
+
+const (
+	upsertInsert upsertKind = iota
+	upsertUpdate
+	upsertTouch
+)
+
+type upsertOp struct {
+	kind upsertKind
+	key  jobKey
+	job  *Job
+}
+
+// bulkBackend is the storage side of bulkUpsert: one lookup of the stored
+// content hashes and one write per batch. write reports the ops that
+// failed by their index in ops. Batches with changed jobs also look up
+// their stored versions and save them as revisions.
+type bulkBackend interface {
+	contentHashes(ctx context.Context, keys []jobKey) (map[jobKey]string, error)
+	stored(ctx context.Context, keys []jobKey) (map[jobKey]*Job, error)
+	write(ctx context.Context, ops []upsertOp, now time.Time) (map[int]string, error)
+	saveRevisions(ctx context.Context, revisions []JobRevision) error
+}
+
+// bulkUpsert stores jobs in batches, classifying each one as new, changed
+// or unchanged by comparing content hashes. Item failures are reported in
+// the result; an error is returned only when a whole batch failed.
+func bulkUpsert(ctx context.Context, backend bulkBackend, jobs []Job) (*BulkResult, error) {
+	result := &BulkResult{Errors: []ItemError{}}
+	now := time.Now()
+	seen := make(map[jobKey]bool, len(jobs))
+
+	fail := func(job *Job, reason string) {
+		result.Failed++
+		result.Errors = append(result.Errors, ItemError{Source: job.Source, SourceID: job.SourceID, Reason: reason})
+	}
+
+	for start := 0; start < len(jobs); start += bulkBatchSize {
+		batch := jobs[start:min(start+bulkBatchSize, len(jobs))]
+
+		ops := make([]upsertOp, 0, len(batch))
+		keys := make([]jobKey, 0, len(batch))
+		for i := range batch {
+			job := batch[i]
+			key := jobKey{job.Source, job.SourceID}
+			if key.source == "" || key.sourceID == "" {
+				fail(&job, "missing source or sourceId")
+				continue
+			}
+			if seen[key] {
+				fail(&job, "duplicate sourceId")
+				continue
+			}
+			seen[key] = true
+
+			job.DedupKey = DedupKey(job.Company, job.Title)
+			job.MinHash = descriptionSignature(job.Description)
+			job.ContentHash = job.Hash()
+			ops = append(ops, upsertOp{key: key, job: &job})
+			keys = append(keys, key)
+		}
+		if len(ops) == 0 {
+			continue
+		}
+
+		hashes, err := backend.contentHashes(ctx, keys)
+		if err != nil {
+			return result, err
+		}
+		var changed []jobKey
+		for i := range ops {
+			stored, exists := hashes[ops[i].key]
+			switch {
+			case !exists:
+				ops[i].kind = upsertInsert
+			case stored == ops[i].job.ContentHash:
+				ops[i].kind = upsertTouch
+			default:
+				ops[i].kind = upsertUpdate
+				changed = append(changed, ops[i].key)
+			}
+		}
+
+		var previous map[jobKey]*Job
+		if len(changed) > 0 {
+			if previous, err = backend.stored(ctx, changed); err != nil {
+				return result, err
+			}
+		}
+
+		failed, err := backend.write(ctx, ops, now)
+		if err != nil {
+			return result, err
+		}
+		var revisions []JobRevision
+		for i, op := range ops {
+			if reason, ok := failed[i]; ok {
+				fail(op.job, reason)
+				continue
+			}
+			switch op.kind {
+			case upsertInsert:
+				result.Added++
+			case upsertUpdate:
+				result.Updated++
+				if rev, ok := revise(previous[op.key], op.job, now); ok {
+					revisions = append(revisions, rev)
+				}
+			case upsertTouch:
+				result.Unchanged++
+			}
+		}
+		// The jobs are already stored, so a lost revision only leaves a
+		// gap in their history
+		if len(revisions) > 0 {
+			if err := backend.saveRevisions(ctx, revisions); err != nil {
+				log.Printf("⚠️ Failed to save %d job revisions: %v", len(revisions), err)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// revise returns the revision recording that job replaced prev, unless
+// only fields derived from the posting changed.
+func revise(prev, job *Job, now time.Time) (JobRevision, bool) {
+	if prev == nil {
+		return JobRevision{}, false
+	}
+	from := prev.Version()
+	changes := Diff(from, job.Version())
+	// A job without a date keeps the date it was first seen
+	if job.PostedAt.IsZero() {
+		changes = withoutField(changes, "postedAt")
+	}
+	if len(changes) == 0 {
+		return JobRevision{}, false
+	}
+	return JobRevision{JobID: prev.ID, Version: from, Fields: changedFields(changes), RevisedAt: now}, true
+}
+
+func withoutField(changes []FieldChange, field string) []FieldChange {
+	kept := changes[:0]
+	for _, c := range changes {
+		if c.Field != field {
+			kept = append(kept, c)
+		}
+	}
+	return kept
+}
+
+// markSeen sets the fields that record a job as currently listed at its
+// source. Seeing a job again revives it if it had expired.
+func markSeen(job *Job, now time.Time) {
+	job.IsActive = true
+	job.MissedRuns = 0
+	job.LastSeenAt = now
+	job.ExpiredAt = nil
+}
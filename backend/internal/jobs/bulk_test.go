@@ -0,0 +1,113 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func sampleJobs(n int) []Job {
+	list := make([]Job, n)
+	for i := range list {
+		list[i] = Job{
+			Title:       fmt.Sprintf("Engineer %d", i),
+			Company:     "Acme",
+			Description: "Build things",
+			Skills:      []string{"go", "mongodb"},
+			Location:    "Remote",
+			Source:      "Example",
+			SourceID:    fmt.Sprint(i),
+			PostedAt:    time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC),
+		}
+	}
+	return list
+}
+
+func TestBulkUpsertCounts(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+
+	first, err := repo.BulkUpsert(ctx, sampleJobs(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Added != 3 || first.Updated != 0 || first.Unchanged != 0 || first.Failed != 0 {
+		t.Errorf("first run = %+v, want 3 added", first)
+	}
+
+	next := sampleJobs(3)
+	next[1].Salary = "USD 100000"
+	next = append(next, Job{Title: "No ID", Source: "Example"}, next[0])
+
+	second, err := repo.BulkUpsert(ctx, next)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.Added != 0 || second.Updated != 1 || second.Unchanged != 2 || second.Failed != 2 {
+		t.Errorf("second run = %+v, want 1 updated, 2 unchanged, 2 failed", second)
+	}
+	if len(second.Errors) != 2 || second.Errors[1].SourceID != "0" || second.Errors[1].Reason != "duplicate sourceId" {
+		t.Errorf("errors = %+v", second.Errors)
+	}
+
+	stored := repo.Jobs()
+	if len(stored) != 3 || stored[1].Salary != "USD 100000" || !stored[0].IsActive {
+		t.Errorf("stored = %+v", stored)
+	}
+}
+
+func TestBulkUpsertUnknownDate(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+
+	list := sampleJobs(1)
+	list[0].PostedAt = time.Time{}
+	list[0].DateUnknown = true
+	if _, err := repo.BulkUpsert(ctx, list); err != nil {
+		t.Fatal(err)
+	}
+	firstSeen := repo.Jobs()[0].PostedAt
+	if firstSeen.IsZero() {
+		t.Fatal("postedAt should fall back to when the job was first seen")
+	}
+
+	list[0].Salary = "USD 100000"
+	result, err := repo.BulkUpsert(ctx, list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Updated != 1 || !repo.Jobs()[0].PostedAt.Equal(firstSeen) {
+		t.Errorf("updated = %d, postedAt = %v, want first seen %v", result.Updated, repo.Jobs()[0].PostedAt, firstSeen)
+	}
+}
+
+func TestBulkUpsertBatches(t *testing.T) {
+	repo := NewMemoryRepository()
+	if _, err := repo.BulkUpsert(context.Background(), sampleJobs(2*bulkBatchSize+1)); err != nil {
+		t.Fatal(err)
+	}
+	// One lookup and one write per batch
+	if repo.RoundTrips != 6 {
+		t.Errorf("round trips = %d, want 6", repo.RoundTrips)
+	}
+}
+
+func BenchmarkBulkUpsert(b *testing.B) {
+	ctx := context.Background()
+	list := sampleJobs(1000)
+
+	repo := NewMemoryRepository()
+	if _, err := repo.BulkUpsert(ctx, list); err != nil {
+		b.Fatal(err)
+	}
+	list[0].Title = "Changed"
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := repo.BulkUpsert(ctx, list); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(repo.RoundTrips)/float64(b.N+1), "roundtrips/op")
+}
@@ -0,0 +1,280 @@
+package jobs
+
+import (
+	"hash/fnv"
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Alternate is another posting of the same role, usually on a different
+// source.
+type Alternate struct {
+	JobID  primitive.ObjectID `json:"jobId" bson:"jobId"`
+	Source string             `json:"source" bson:"source"`
+	URL    string             `json:"url" bson:"url"`
+}
+
+const (
+	// minHashSize is the number of hash functions in a description
+	// signature.
+	minHashSize = 64
+
+	// duplicateSimilarity is the estimated Jaccard similarity of two
+	// descriptions above which jobs with the same dedup key are merged.
+	duplicateSimilarity = 0.5
+
+	shingleWords = 3
+)
+
+var (
+	dedupTagPattern    = regexp.MustCompile(`<[^>]*>`)
+	dedupParenPattern  = regexp.MustCompile(`\([^)]*\)|\[[^\]]*\]`)
+	dedupNonWord       = regexp.MustCompile(`[^\p{L}\p{N}+#]+`)
+	companySuffixes    = map[string]bool{"inc": true, "llc": true, "ltd": true, "limited": true, "gmbh": true, "corp": true, "corporation": true, "co": true, "sa": true, "bv": true, "ag": true, "plc": true}
+	titleAbbreviations = map[string]string{"sr": "senior", "jr": "junior", "eng": "engineer", "dev": "developer", "mgr": "manager"}
+	titleNoise         = map[string]bool{"remote": true, "hybrid": true}
+)
+
+// DedupKey combines a job's normalized company and title. Postings of the
+// same role on different sources share it even when they are formatted
+// differently, e.g. "Acme, Inc." / "Sr. Backend Engineer (Remote)" and
+// "Acme" / "Senior Backend Engineer".
+func DedupKey(company, title string) string {
+	var companyWords []string
+	for _, w := range normalizeWords(company) {
+		if !companySuffixes[w] {
+			companyWords = append(companyWords, w)
+		}
+	}
+
+	var titleWords []string
+	for _, w := range normalizeWords(dedupParenPattern.ReplaceAllString(title, " ")) {
+		if full, ok := titleAbbreviations[w]; ok {
+			w = full
+		}
+		if !titleNoise[w] {
+			titleWords = append(titleWords, w)
+		}
+	}
+
+	if len(companyWords) == 0 || len(titleWords) == 0 {
+		return ""
+	}
+	return strings.Join(companyWords, " ") + "|" + strings.Join(titleWords, " ")
+}
+
+func normalizeWords(s string) []string {
+	return strings.Fields(dedupNonWord.ReplaceAllString(strings.ToLower(s), " "))
+}
+
+// descriptionSignature is a MinHash signature of the word shingles in a
+// job description, or nil for descriptions too short to compare.
+func descriptionSignature(description string) []uint32 {
+	text := html.UnescapeString(dedupTagPattern.ReplaceAllString(description, " "))
+	words := normalizeWords(text)
+	if len(words) < shingleWords {
+		return nil
+	}
+
+	sig := make([]uint32, minHashSize)
+	for i := range sig {
+		sig[i] = ^uint32(0)
+	}
+	for i := 0; i+shingleWords <= len(words); i++ {
+		h := fnv.New64a()
+		h.Write([]byte(strings.Join(words[i:i+shingleWords], " ")))
+		base := h.Sum64()
+
+		// Derive the k hash functions from one base hash
+		for k := range sig {
+			v := uint32(mix64(base + uint64(k)*0x9e3779b97f4a7c15))
+			if v < sig[k] {
+				sig[k] = v
+			}
+		}
+	}
+	return sig
+}
+
+// mix64 is the splitmix64 finalizer.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// similarity estimates the Jaccard similarity of two descriptions from
+// their signatures. Jobs missing a description count as similar, so the
+// dedup key alone decides.
+func similarity(a, b []uint32) float64 {
+	if len(a) == 0 || len(b) == 0 || len(a) != len(b) {
+		return 1
+	}
+	same := 0
+	for i := range a {
+		if a[i] == b[i] {
+			same++
+		}
+	}
+	return float64(same) / float64(len(a))
+}
+
+// directSources post on the employer's own board, so their copy of a role
+// is preferred as the canonical job.
+var directSources = map[string]bool{"Lever": true, "Greenhouse": true, "CareerPages": true}
+
+// clusterUpdate is the duplicate state a job should have after clustering.
+type clusterUpdate struct {
+	id          primitive.ObjectID
+	duplicateOf *primitive.ObjectID
+	alternates  []Alternate
+}
+
+// clusterDuplicates groups active jobs that share a dedup key, come from
+// different sources and have similar descriptions. A cluster holds at most
+// one job from each source. Each cluster gets a
+// canonical job listing the others as alternates; the others point at it.
+// Only jobs whose stored state differs from the result are returned.
+func clusterDuplicates(list []Job) []clusterUpdate {
+	byKey := make(map[string][]int)
+	for i, job := range list {
+		if job.IsActive && job.DedupKey != "" {
+			byKey[job.DedupKey] = append(byKey[job.DedupKey], i)
+		}
+	}
+
+	want := make(map[int]clusterUpdate, len(list))
+	for _, members := range byKey {
+		parent := make(map[int]int, len(members))
+		var find func(int) int
+		find = func(i int) int {
+			if parent[i] != i {
+				parent[i] = find(parent[i])
+			}
+			return parent[i]
+		}
+		// sources lists the sources in each cluster, by root
+		sources := make(map[int]map[string]bool, len(members))
+		for _, i := range members {
+			parent[i] = i
+			sources[i] = map[string]bool{list[i].Source: true}
+		}
+
+		type pair struct {
+			i, j int
+			sim  float64
+		}
+		var pairs []pair
+		for x, i := range members {
+			for _, j := range members[x+1:] {
+				if list[i].Source == list[j].Source {
+					continue
+				}
+				if sim := similarity(list[i].MinHash, list[j].MinHash); sim >= duplicateSimilarity {
+					pairs = append(pairs, pair{i, j, sim})
+				}
+			}
+		}
+		// Merge the closest pairs first, and never two clusters that share
+		// a source, so distinct roles on one source stay apart
+		sort.SliceStable(pairs, func(a, b int) bool { return pairs[a].sim > pairs[b].sim })
+		for _, p := range pairs {
+			ri, rj := find(p.i), find(p.j)
+			if ri == rj || overlapping(sources[ri], sources[rj]) {
+				continue
+			}
+			parent[ri] = rj
+			for src := range sources[ri] {
+				sources[rj][src] = true
+			}
+			delete(sources, ri)
+		}
+
+		clusters := make(map[int][]int)
+		for _, i := range members {
+			root := find(i)
+			clusters[root] = append(clusters[root], i)
+		}
+		for _, cluster := range clusters {
+			if len(cluster) < 2 {
+				continue
+			}
+			sort.Slice(cluster, func(a, b int) bool { return preferCanonical(&list[cluster[a]], &list[cluster[b]]) })
+
+			canonical := list[cluster[0]].ID
+			alternates := make([]Alternate, 0, len(cluster)-1)
+			for _, i := range cluster[1:] {
+				alternates = append(alternates, Alternate{JobID: list[i].ID, Source: list[i].Source, URL: list[i].URL})
+				want[i] = clusterUpdate{id: list[i].ID, duplicateOf: &canonical}
+			}
+			want[cluster[0]] = clusterUpdate{id: canonical, alternates: alternates}
+		}
+	}
+
+	var updates []clusterUpdate
+	for i, job := range list {
+		update, ok := want[i]
+		if !ok {
+			update = clusterUpdate{id: job.ID}
+		}
+		if !sameClusterState(&job, update) {
+			updates = append(updates, update)
+		}
+	}
+	return updates
+}
+
+func overlapping(a, b map[string]bool) bool {
+	for src := range a {
+		if b[src] {
+			return true
+		}
+	}
+	return false
+}
+
+// preferCanonical orders a cluster: direct employer postings first, then
+// the earliest posted, then the first stored.
+func preferCanonical(a, b *Job) bool {
+	if directSources[a.Source] != directSources[b.Source] {
+		return directSources[a.Source]
+	}
+	if !a.PostedAt.Equal(b.PostedAt) {
+		return earlier(a.PostedAt, b.PostedAt)
+	}
+	return a.ID.Hex() < b.ID.Hex()
+}
+
+// earlier treats a zero time as unknown and orders it last.
+func earlier(a, b time.Time) bool {
+	if a.IsZero() || b.IsZero() {
+		return b.IsZero() && !a.IsZero()
+	}
+	return a.Before(b)
+}
+
+func sameClusterState(job *Job, update clusterUpdate) bool {
+	if (job.DuplicateOf == nil) != (update.duplicateOf == nil) {
+		return false
+	}
+	if job.DuplicateOf != nil && *job.DuplicateOf != *update.duplicateOf {
+		return false
+	}
+	if len(job.Alternates) != len(update.alternates) {
+		return false
+	}
+	for i := range job.Alternates {
+		if job.Alternates[i] != update.alternates[i] {
+			return false
+		}
+	}
+	return true
+}
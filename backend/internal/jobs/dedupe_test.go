@@ -0,0 +1,117 @@
+package jobs
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const acmeDescription = `<p>Acme is looking for a senior backend engineer to design, build and
+operate the services behind our payments platform. You will work with Go, PostgreSQL
+and Kafka, own features end to end and mentor other engineers on the team.</p>`
+
+func TestDedupKey(t *testing.T) {
+	a := DedupKey("Acme, Inc.", "Sr. Backend Engineer (Remote)")
+	b := DedupKey("ACME", "Senior Backend Engineer")
+	if a == "" || a != b {
+		t.Errorf("keys differ: %q vs %q", a, b)
+	}
+	if c := DedupKey("Acme", "Senior Frontend Engineer"); c == a {
+		t.Errorf("different roles share key %q", c)
+	}
+}
+
+func TestClusterDuplicates(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+	start := time.Now()
+
+	posted := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+	list := []Job{
+		{Source: "RemoteOK", SourceID: "1", Company: "Acme Inc", Title: "Senior Backend Engineer", Description: acmeDescription, URL: "https://remoteok.com/1", PostedAt: posted},
+		{Source: "Remotive", SourceID: "2", Company: "Acme", Title: "Sr. Backend Engineer (Remote)", Description: "<div>" + acmeDescription + "</div>", URL: "https://remotive.com/2", PostedAt: posted.Add(time.Hour)},
+		{Source: "Greenhouse", SourceID: "acme/3", Company: "Acme", Title: "Senior Backend Engineer", Description: acmeDescription + " Apply now.", URL: "https://boards.greenhouse.io/acme/3", PostedAt: posted.Add(2 * time.Hour)},
+		{Source: "Remotive", SourceID: "4", Company: "Acme", Title: "Senior Backend Engineer", Description: strings.Repeat("A completely different role about sales territories. ", 5), URL: "https://remotive.com/4"},
+	}
+	if _, err := repo.BulkUpsert(ctx, list); err != nil {
+		t.Fatal(err)
+	}
+
+	changed, err := repo.ClusterDuplicates(ctx, start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed != 3 {
+		t.Errorf("changed = %d, want 3", changed)
+	}
+
+	bySource := make(map[string]Job)
+	for _, job := range repo.Jobs() {
+		bySource[job.Source+"/"+job.SourceID] = job
+	}
+	canonical := bySource["Greenhouse/acme/3"]
+	if canonical.DuplicateOf != nil || len(canonical.Alternates) != 2 {
+		t.Fatalf("canonical = %+v, want the company board posting with 2 alternates", canonical)
+	}
+	if canonical.Alternates[0].Source != "RemoteOK" || canonical.Alternates[1].URL != "https://remotive.com/2" {
+		t.Errorf("alternates = %+v", canonical.Alternates)
+	}
+	for _, key := range []string{"RemoteOK/1", "Remotive/2"} {
+		if dup := bySource[key].DuplicateOf; dup == nil || *dup != canonical.ID {
+			t.Errorf("%s duplicateOf = %v, want %v", key, dup, canonical.ID)
+		}
+	}
+	if other := bySource["Remotive/4"]; other.DuplicateOf != nil || len(other.Alternates) != 0 {
+		t.Errorf("unrelated posting was clustered: %+v", other)
+	}
+
+	// Clustering is stable once applied
+	if changed, _ := repo.ClusterDuplicates(ctx, start); changed != 0 {
+		t.Errorf("second pass changed %d jobs", changed)
+	}
+
+	// When the canonical posting expires the cluster re-forms without it
+	expiredAt := time.Now()
+	repo.jobs[jobKey{"Greenhouse", "acme/3"}].IsActive = false
+	repo.jobs[jobKey{"Greenhouse", "acme/3"}].ExpiredAt = &expiredAt
+	if _, err := repo.ClusterDuplicates(ctx, expiredAt); err != nil {
+		t.Fatal(err)
+	}
+	for _, job := range repo.Jobs() {
+		switch job.Source + "/" + job.SourceID {
+		case "RemoteOK/1":
+			if job.DuplicateOf != nil || len(job.Alternates) != 1 {
+				t.Errorf("new canonical = %+v", job)
+			}
+		case "Greenhouse/acme/3":
+			if job.DuplicateOf != nil || len(job.Alternates) != 0 {
+				t.Errorf("expired job still clustered: %+v", job)
+			}
+		}
+	}
+}
+
+func TestClusterDuplicatesKeepsSourcesApart(t *testing.T) {
+	// A and C are different roles on one source that both resemble B
+	list := []Job{
+		{ID: primitive.NewObjectID(), Source: "RemoteOK", DedupKey: "acme|engineer", IsActive: true, MinHash: []uint32{1, 1, 1, 1, 0, 0, 0, 0}},
+		{ID: primitive.NewObjectID(), Source: "Remotive", DedupKey: "acme|engineer", IsActive: true, MinHash: []uint32{1, 1, 1, 1, 2, 2, 2, 2}},
+		{ID: primitive.NewObjectID(), Source: "RemoteOK", DedupKey: "acme|engineer", IsActive: true, MinHash: []uint32{3, 3, 3, 3, 2, 2, 2, 2}},
+	}
+
+	clustered := make(map[primitive.ObjectID]bool)
+	for _, u := range clusterDuplicates(list) {
+		if u.duplicateOf != nil {
+			clustered[u.id], clustered[*u.duplicateOf] = true, true
+		}
+	}
+	if len(clustered) != 2 {
+		t.Fatalf("clustered %d jobs, want one pair", len(clustered))
+	}
+	if clustered[list[0].ID] && clustered[list[2].ID] {
+		t.Error("two RemoteOK postings were clustered together")
+	}
+}
@@ -1,19 +1,23 @@
 package jobs
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/hiresense/backend/internal/sources"
 )
 
 type Handler struct {
-	repo *Repository
+	repo    *Repository
+	sources *sources.Repository
 }
 
 func NewHandler() *Handler {
 	return &Handler{
-		repo: NewRepository(),
+		repo:    NewRepository(),
+		sources: sources.NewRepository(),
 	}
 }
 
@@ -22,6 +26,7 @@ func (h *Handler) RegisterRoutes(r *gin.RouterGroup, authMiddleware, optionalAut
 	r.GET("/saved", authMiddleware, h.GetSavedJobs)
 	r.GET("/sources", h.GetSources)
 	r.GET("/:id", optionalAuth, h.GetJob)
+	r.GET("/:id/history", h.GetJobHistory)
 	r.POST("/:id/save", authMiddleware, h.SaveJob)
 	r.DELETE("/:id/save", authMiddleware, h.UnsaveJob)
 	r.POST("/:id/hide", authMiddleware, h.HideJob)
@@ -40,6 +45,11 @@ func (h *Handler) GetJobs(c *gin.Context) {
 		filter.Skills = strings.Split(skills, ",")
 	}
 
+	if err := filter.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	response, err := h.repo.FindAll(c.Request.Context(), &filter)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch jobs"})
@@ -52,15 +62,45 @@ func (h *Handler) GetJobs(c *gin.Context) {
 func (h *Handler) GetJob(c *gin.Context) {
 	jobID := c.Param("id")
 
+	format := c.DefaultQuery("format", "html")
+	if format != "html" && format != "markdown" && format != "text" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be html, markdown or text"})
+		return
+	}
+
 	job, err := h.repo.FindByID(c.Request.Context(), jobID)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
 		return
 	}
 
+	// Jobs stored before sanitization have no renditions; keep their HTML
+	switch {
+	case format == "markdown" && job.DescriptionMarkdown != "":
+		job.Description = job.DescriptionMarkdown
+	case format == "text" && job.DescriptionText != "":
+		job.Description = job.DescriptionText
+	}
+
 	c.JSON(http.StatusOK, job)
 }
 
+// GetJobHistory lists the changes made to a posting since it was first
+// scraped.
+func (h *Handler) GetJobHistory(c *gin.Context) {
+	history, err := h.repo.History(c.Request.Context(), c.Param("id"))
+	if errors.Is(err, ErrJobNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch job history"})
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
 func (h *Handler) GetSavedJobs(c *gin.Context) {
 	userID := c.GetString("userId")
 
@@ -114,7 +154,33 @@ func (h *Handler) TrackApply(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Application tracked"})
 }
 
+// GetSources lists the enabled sources with their active job counts.
 func (h *Handler) GetSources(c *gin.Context) {
-	sources := []string{"RemoteOK", "WeWorkRemotely", "Remotive", "Lever"}
-	c.JSON(http.StatusOK, sources)
+	ctx := c.Request.Context()
+
+	enabled, err := h.sources.Enabled(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch sources"})
+		return
+	}
+	counts, err := h.repo.ActiveCountsBySource(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count jobs"})
+		return
+	}
+
+	result := make([]SourceSummary, len(enabled))
+	for i, src := range enabled {
+		result[i] = SourceSummary{
+			ID:          src.ID,
+			DisplayName: src.DisplayName,
+			LogoURL:     src.LogoURL,
+			JobCount:    counts[src.ID],
+		}
+	}
+	// Imported jobs have no registry entry but can still be filtered on
+	if n := counts[ImportSource]; n > 0 {
+		result = append(result, SourceSummary{ID: ImportSource, DisplayName: "Imported", JobCount: n})
+	}
+	c.JSON(http.StatusOK, result)
 }
@@ -0,0 +1,159 @@
+package jobs
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Seniority levels, from least to most senior. Staff and lead are the same
+// step: one leans technical, the other towards managing people.
+const (
+	SeniorityIntern = "intern"
+	SeniorityJunior = "junior"
+	SeniorityMid    = "mid"
+	SenioritySenior = "senior"
+	SeniorityStaff  = "staff"
+	SeniorityLead   = "lead"
+)
+
+// Employment types.
+const (
+	EmploymentFullTime  = "full-time"
+	EmploymentContract  = "contract"
+	EmploymentPartTime  = "part-time"
+	EmploymentFreelance = "freelance"
+)
+
+var seniorityRank = map[string]int{
+	SeniorityIntern: 0,
+	SeniorityJunior: 1,
+	SeniorityMid:    2,
+	SenioritySenior: 3,
+	SeniorityStaff:  4,
+	SeniorityLead:   4,
+}
+
+type levelPattern struct {
+	value   string
+	pattern *regexp.Regexp
+}
+
+// seniorityPatterns are tried in order on titles and tags, so "Senior
+// Staff Engineer" is staff and "Senior Intern" is an internship.
+var seniorityPatterns = []levelPattern{
+	{SeniorityIntern, regexp.MustCompile(`(?i)\b(intern|internship|trainee|apprentice(ship)?)\b`)},
+	{SeniorityStaff, regexp.MustCompile(`(?i)\b(staff|principal|distinguished)\b`)},
+	{SeniorityLead, regexp.MustCompile(`(?i)\b(lead|head of|director|vp|vice president|engineering manager)\b`)},
+	{SenioritySenior, regexp.MustCompile(`(?i)\b(senior|sr|snr|iii|iv)\b`)},
+	{SeniorityJunior, regexp.MustCompile(`(?i)\b(junior|jr|entry[- ]level|graduate|new grad)\b`)},
+	{SeniorityMid, regexp.MustCompile(`(?i)\b(mid|mid[- ]level|intermediate|ii)\b`)},
+}
+
+// yearsPattern finds "3+ years of experience", "2-4 years' professional
+// experience" and the like in descriptions.
+var yearsPattern = regexp.MustCompile(`(?i)\b(\d{1,2})\s*\+?\s*(?:(?:-|–|to)\s*\d{1,2}\s*\+?\s*)?years?['’]?\s+(?:of\s+)?(?:[\w-]+\s+){0,3}?experience`)
+
+// employmentPatterns match titles and tags, which are short enough that a
+// bare "contract" means the job is one.
+var employmentPatterns = []levelPattern{
+	{EmploymentFreelance, regexp.MustCompile(`(?i)\b(freelance|freelancer)\b`)},
+	{EmploymentContract, regexp.MustCompile(`(?i)\b(contract|contractor|contract[- ]to[- ]hire|c2c|1099|fixed[- ]term|temporary)\b`)},
+	{EmploymentPartTime, regexp.MustCompile(`(?i)\bpart[- ]?time\b`)},
+	{EmploymentFullTime, regexp.MustCompile(`(?i)\b(full[- ]?time|permanent)\b`)},
+}
+
+// descriptionEmploymentPatterns need more context, since descriptions talk
+// about "smart contracts" and "permanent solutions".
+var descriptionEmploymentPatterns = []levelPattern{
+	{EmploymentFreelance, regexp.MustCompile(`(?i)\bfreelance (role|position|basis|project|work)\b`)},
+	{EmploymentContract, regexp.MustCompile(`(?i)\b(contract (role|position|basis|job|engagement)|contract[- ]to[- ]hire|c2c|1099 contractor|fixed[- ]term)\b`)},
+	{EmploymentPartTime, regexp.MustCompile(`(?i)\bpart[- ]?time\b`)},
+	{EmploymentFullTime, regexp.MustCompile(`(?i)\b(full[- ]?time|permanent (role|position|contract))\b`)},
+}
+
+// InferSeniority guesses a job's seniority from its title, then its tags,
+// then the years of experience its description asks for. It returns "" if
+// none of them say.
+func InferSeniority(title string, tags []string, description string) string {
+	for _, text := range []string{title, strings.Join(tags, ", ")} {
+		for _, p := range seniorityPatterns {
+			if p.pattern.MatchString(text) {
+				return p.value
+			}
+		}
+	}
+
+	m := yearsPattern.FindStringSubmatch(description)
+	if m == nil {
+		return ""
+	}
+	years, _ := strconv.Atoi(m[1])
+	switch {
+	case years < 2:
+		return SeniorityJunior
+	case years < 5:
+		return SeniorityMid
+	default:
+		return SenioritySenior
+	}
+}
+
+// InferEmploymentType guesses whether a job is full-time, part-time,
+// contract or freelance from its title, then its tags, then its
+// description. It returns "" if none of them say.
+func InferEmploymentType(title string, tags []string, description string) string {
+	for _, text := range []string{title, strings.Join(tags, ", ")} {
+		if t := earliestMatch(employmentPatterns, text); t != "" {
+			return t
+		}
+	}
+	return earliestMatch(descriptionEmploymentPatterns, description)
+}
+
+// NormalizeEmploymentType maps a source's own employment type, such as
+// schema.org's "FULL_TIME" or Lever's "Full-time", to one of ours.
+func NormalizeEmploymentType(s string) string {
+	return earliestMatch(employmentPatterns, strings.ReplaceAll(s, "_", " "))
+}
+
+// earliestMatch returns the pattern matching first in text, so "full-time,
+// contractors welcome" is full-time.
+func earliestMatch(patterns []levelPattern, text string) string {
+	best, at := "", len(text)+1
+	for _, p := range patterns {
+		if loc := p.pattern.FindStringIndex(text); loc != nil && loc[0] < at {
+			best, at = p.value, loc[0]
+		}
+	}
+	return best
+}
+
+// IsSeniority reports whether s is one of the seniority levels.
+func IsSeniority(s string) bool {
+	_, ok := seniorityRank[s]
+	return ok
+}
+
+// IsEmploymentType reports whether s is one of the employment types.
+func IsEmploymentType(s string) bool {
+	switch s {
+	case EmploymentFullTime, EmploymentContract, EmploymentPartTime, EmploymentFreelance:
+		return true
+	}
+	return false
+}
+
+// SeniorityGap is how many steps apart two seniority levels are. ok is
+// false when either level is unknown.
+func SeniorityGap(a, b string) (gap int, ok bool) {
+	ra, okA := seniorityRank[a]
+	rb, okB := seniorityRank[b]
+	if !okA || !okB {
+		return 0, false
+	}
+	if ra > rb {
+		return ra - rb, true
+	}
+	return rb - ra, true
+}
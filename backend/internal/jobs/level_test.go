@@ -0,0 +1,67 @@
+package jobs
+
+import "testing"
+
+func TestInferSeniority(t *testing.T) {
+	tests := []struct {
+		title       string
+		tags        []string
+		description string
+		want        string
+	}{
+		{"Senior Staff Engineer", nil, "", SeniorityStaff},
+		{"Sr. Backend Developer", nil, "", SenioritySenior},
+		{"Software Engineering Intern", nil, "", SeniorityIntern},
+		{"Tech Lead, Payments", nil, "", SeniorityLead},
+		{"Junior Frontend Developer", nil, "", SeniorityJunior},
+		{"Software Engineer II", nil, "", SeniorityMid},
+		{"Backend Engineer", []string{"golang", "senior"}, "", SenioritySenior},
+		{"Backend Engineer", nil, "You have 3+ years of professional experience with Go.", SeniorityMid},
+		{"Backend Engineer", nil, "1-2 years experience preferred.", SeniorityJunior},
+		{"Backend Engineer", nil, "We value 7 years' hands-on experience.", SenioritySenior},
+		{"Backend Engineer", nil, "Join a team of 40 engineers.", ""},
+	}
+	for _, tt := range tests {
+		if got := InferSeniority(tt.title, tt.tags, tt.description); got != tt.want {
+			t.Errorf("InferSeniority(%q, %v, %q) = %q, want %q", tt.title, tt.tags, tt.description, got, tt.want)
+		}
+	}
+}
+
+func TestInferEmploymentType(t *testing.T) {
+	tests := []struct {
+		title       string
+		tags        []string
+		description string
+		want        string
+	}{
+		{"React Developer (Contract)", nil, "", EmploymentContract},
+		{"Designer", []string{"freelance", "figma"}, "", EmploymentFreelance},
+		{"Support Engineer", nil, "This is a part-time role, 20 hours a week.", EmploymentPartTime},
+		{"Backend Engineer", nil, "A full-time position. Contract-to-hire also possible.", EmploymentFullTime},
+		{"Solidity Engineer", nil, "You will audit smart contract code.", ""},
+	}
+	for _, tt := range tests {
+		if got := InferEmploymentType(tt.title, tt.tags, tt.description); got != tt.want {
+			t.Errorf("InferEmploymentType(%q, %v, %q) = %q, want %q", tt.title, tt.tags, tt.description, got, tt.want)
+		}
+	}
+
+	for in, want := range map[string]string{"FULL_TIME": EmploymentFullTime, "Full-time": EmploymentFullTime, "CONTRACTOR": EmploymentContract, "INTERN": ""} {
+		if got := NormalizeEmploymentType(in); got != want {
+			t.Errorf("NormalizeEmploymentType(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestSeniorityGap(t *testing.T) {
+	if gap, ok := SeniorityGap(SeniorityJunior, SeniorityStaff); !ok || gap != 3 {
+		t.Errorf("SeniorityGap(junior, staff) = %d, %v", gap, ok)
+	}
+	if gap, ok := SeniorityGap(SeniorityLead, SeniorityStaff); !ok || gap != 0 {
+		t.Errorf("SeniorityGap(lead, staff) = %d, %v", gap, ok)
+	}
+	if _, ok := SeniorityGap(SeniorityMid, ""); ok {
+		t.Error("SeniorityGap with an unknown level should not be ok")
+	}
+}
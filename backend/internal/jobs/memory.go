@@ -0,0 +1,200 @@
+package jobs
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryRepository is an in-memory Store. It shares BulkUpsert's batching
+// and change detection with Repository, which makes it useful for tests
+// and benchmarks that should not need a database.
+type MemoryRepository struct {
+	mu        sync.Mutex
+	jobs      map[jobKey]*Job
+	revisions []JobRevision
+
+	// RoundTrips counts lookups and writes, each of which would be one
+	// request to MongoDB.
+	RoundTrips int
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{jobs: make(map[jobKey]*Job)}
+}
+
+func (r *MemoryRepository) BulkUpsert(ctx context.Context, jobs []Job) (*BulkResult, error) {
+	return bulkUpsert(ctx, r, jobs)
+}
+
+// Jobs returns a copy of the stored jobs ordered by source and sourceId.
+func (r *MemoryRepository) Jobs() []Job {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make([]Job, 0, len(r.jobs))
+	for _, job := range r.jobs {
+		result = append(result, *job)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Source != result[j].Source {
+			return result[i].Source < result[j].Source
+		}
+		return result[i].SourceID < result[j].SourceID
+	})
+	return result
+}
+
+func (r *MemoryRepository) contentHashes(ctx context.Context, keys []jobKey) (map[jobKey]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.RoundTrips++
+
+	hashes := make(map[jobKey]string, len(keys))
+	for _, key := range keys {
+		if job, ok := r.jobs[key]; ok {
+			hashes[key] = job.ContentHash
+		}
+	}
+	return hashes, ctx.Err()
+}
+
+// Revisions returns a copy of the stored revisions, oldest first.
+func (r *MemoryRepository) Revisions() []JobRevision {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]JobRevision{}, r.revisions...)
+}
+
+func (r *MemoryRepository) stored(ctx context.Context, keys []jobKey) (map[jobKey]*Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.RoundTrips++
+
+	stored := make(map[jobKey]*Job, len(keys))
+	for _, key := range keys {
+		if job, ok := r.jobs[key]; ok {
+			copied := *job
+			stored[key] = &copied
+		}
+	}
+	return stored, ctx.Err()
+}
+
+func (r *MemoryRepository) saveRevisions(ctx context.Context, revisions []JobRevision) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.RoundTrips++
+
+	for _, rev := range revisions {
+		rev.ID = primitive.NewObjectID()
+		r.revisions = append(r.revisions, rev)
+	}
+	return ctx.Err()
+}
+
+func (r *MemoryRepository) write(ctx context.Context, ops []upsertOp, now time.Time) (map[int]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.RoundTrips++
+
+	for _, op := range ops {
+		stored, exists := r.jobs[op.key]
+		if op.kind == upsertTouch && exists {
+			markSeen(stored, now)
+			continue
+		}
+
+		job := *op.job
+		markSeen(&job, now)
+		job.ID = primitive.NewObjectID()
+		job.ScrapedAt = now
+		if exists {
+			job.ID = stored.ID
+			job.ScrapedAt = stored.ScrapedAt
+		}
+		if job.PostedAt.IsZero() {
+			job.PostedAt = job.ScrapedAt
+			if exists && !stored.PostedAt.IsZero() {
+				job.PostedAt = stored.PostedAt
+			}
+		}
+		r.jobs[op.key] = &job
+	}
+	return nil, nil
+}
+
+func (r *MemoryRepository) ExpireMissing(ctx context.Context, source string, runStartedAt time.Time, maxMissedRuns int, maxAge time.Duration) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var expired int64
+	now := time.Now()
+	for _, job := range r.jobs {
+		if job.Source != source || !job.IsActive || !job.LastSeenAt.Before(runStartedAt) {
+			continue
+		}
+		job.MissedRuns++
+		if (maxMissedRuns > 0 && job.MissedRuns >= maxMissedRuns) ||
+			(maxAge > 0 && job.LastSeenAt.Before(now.Add(-maxAge))) {
+			expireJob(job, now)
+			expired++
+		}
+	}
+	return expired, nil
+}
+
+func (r *MemoryRepository) ExpirePastValidThrough(ctx context.Context, source string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var expired int64
+	now := time.Now()
+	for _, job := range r.jobs {
+		if job.Source == source && job.IsActive && job.ValidThrough != nil && job.ValidThrough.Before(now) {
+			expireJob(job, now)
+			expired++
+		}
+	}
+	return expired, nil
+}
+
+func (r *MemoryRepository) ClusterDuplicates(ctx context.Context, since time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	touched := make(map[string]bool)
+	for _, job := range r.jobs {
+		if !job.LastSeenAt.Before(since) || (job.ExpiredAt != nil && !job.ExpiredAt.Before(since)) {
+			touched[job.DedupKey] = true
+		}
+	}
+
+	byID := make(map[primitive.ObjectID]*Job)
+	var candidates []Job
+	for _, job := range r.jobs {
+		if job.DedupKey != "" && touched[job.DedupKey] {
+			candidates = append(candidates, *job)
+			byID[job.ID] = job
+		}
+	}
+
+	updates := clusterDuplicates(candidates)
+	for _, u := range updates {
+		job := byID[u.id]
+		job.DuplicateOf = u.duplicateOf
+		job.Alternates = u.alternates
+	}
+	return len(updates), nil
+}
+
+func expireJob(job *Job, now time.Time) {
+	job.IsActive = false
+	job.ExpiredAt = &now
+}
@@ -1,27 +1,107 @@
 package jobs
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"time"
 
+	"github.com/hiresense/backend/internal/location"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// ImportSource is the source of jobs imported by admins rather than
+// scraped.
+const ImportSource = "Import"
+
 type Job struct {
-	ID          primitive.ObjectID `json:"_id,omitempty" bson:"_id,omitempty"`
-	Title       string             `json:"title" bson:"title"`
-	Company     string             `json:"company" bson:"company"`
-	Description string             `json:"description" bson:"description"`
-	Skills      []string           `json:"skills" bson:"skills"`
-	Salary      string             `json:"salary" bson:"salary"`
-	Location    string             `json:"location" bson:"location"`
-	Source      string             `json:"source" bson:"source"`
-	URL         string             `json:"url" bson:"url"`
-	SourceID    string             `json:"sourceId" bson:"sourceId"`
-	PostedAt    time.Time          `json:"postedAt" bson:"postedAt"`
-	ScrapedAt   time.Time          `json:"scrapedAt" bson:"scrapedAt"`
-	AIScore     float64            `json:"aiScore,omitempty" bson:"aiScore,omitempty"`
-	MatchReason string             `json:"matchReason,omitempty" bson:"matchReason,omitempty"`
-	IsActive    bool               `json:"isActive" bson:"isActive"`
+	ID           primitive.ObjectID `json:"_id,omitempty" bson:"_id,omitempty"`
+	Title        string             `json:"title" bson:"title"`
+	Company      string             `json:"company" bson:"company"`
+	Description  string             `json:"description" bson:"description"`
+	Skills       []string           `json:"skills" bson:"skills"`
+	Salary       string             `json:"salary" bson:"salary"`
+	SalaryInfo   *SalaryInfo        `json:"salaryInfo,omitempty" bson:"salaryInfo"`
+	Location     string             `json:"location" bson:"location"`
+	LocationInfo *location.Info     `json:"locationInfo,omitempty" bson:"locationInfo"`
+	Source       string             `json:"source" bson:"source"`
+	URL          string             `json:"url" bson:"url"`
+	SourceID     string             `json:"sourceId" bson:"sourceId"`
+	PostedAt     time.Time          `json:"postedAt" bson:"postedAt,omitempty"`
+	ValidThrough *time.Time         `json:"validThrough,omitempty" bson:"validThrough,omitempty"`
+	ScrapedAt    time.Time          `json:"scrapedAt" bson:"scrapedAt,omitempty"`
+	AIScore      float64            `json:"aiScore,omitempty" bson:"aiScore,omitempty"`
+	MatchReason  string             `json:"matchReason,omitempty" bson:"matchReason,omitempty"`
+	IsActive     bool               `json:"isActive" bson:"isActive"`
+
+	// DateUnknown marks jobs whose source gave no usable posting date.
+	// Their PostedAt is when the job was first scraped.
+	DateUnknown bool `json:"dateUnknown,omitempty" bson:"dateUnknown"`
+
+	// Inferred at ingest; empty when the posting does not say
+	Seniority      string `json:"seniority,omitempty" bson:"seniority"`
+	EmploymentType string `json:"employmentType,omitempty" bson:"employmentType"`
+
+	// Expiry tracking: when the source last listed the job, how many
+	// successful runs of its source have missed it since, and when it
+	// was deactivated.
+	LastSeenAt time.Time  `json:"lastSeenAt" bson:"lastSeenAt"`
+	MissedRuns int        `json:"-" bson:"missedRuns"`
+	ExpiredAt  *time.Time `json:"expiredAt,omitempty" bson:"expiredAt,omitempty"`
+
+	// Hash of the scraped content, used to skip rewriting unchanged jobs
+	ContentHash string `json:"-" bson:"contentHash"`
+
+	// Renditions of the sanitized HTML Description, served by
+	// GET /jobs/:id?format=text|markdown
+	DescriptionText     string `json:"-" bson:"descriptionText"`
+	DescriptionMarkdown string `json:"-" bson:"descriptionMarkdown"`
+
+	// Where Skills came from: the source's own tags, or matched in the
+	// title and description against the skill taxonomy
+	TagSkills      []string `json:"tagSkills,omitempty" bson:"tagSkills"`
+	InferredSkills []string `json:"inferredSkills,omitempty" bson:"inferredSkills"`
+
+	// Cross-source duplicates share a dedup key and similar descriptions.
+	// The canonical job of a cluster lists the others as alternates; they
+	// point back at it with DuplicateOf.
+	DedupKey    string              `json:"-" bson:"dedupKey"`
+	MinHash     []uint32            `json:"-" bson:"minHash"`
+	DuplicateOf *primitive.ObjectID `json:"duplicateOf,omitempty" bson:"duplicateOf,omitempty"`
+	Alternates  []Alternate         `json:"alternates,omitempty" bson:"alternates,omitempty"`
+}
+
+// Hash returns a digest of what the job's source posted. Fields derived
+// from it, such as salaryInfo, locationInfo and seniority, are left out so
+// a change to the exchange rates, location parser or inference rules does
+// not count every stored job as updated. Server-managed fields such as
+// IsActive and LastSeenAt are left out too.
+func (j *Job) Hash() string {
+	content, _ := json.Marshal(struct {
+		Title        string
+		Company      string
+		Description  string
+		Skills       []string
+		Salary       string
+		Location     string
+		URL          string
+		PostedAt     time.Time
+		ValidThrough *time.Time
+	}{j.Title, j.Company, j.Description, j.Skills, j.Salary, j.Location, j.URL, j.PostedAt.UTC(), j.ValidThrough})
+
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// SavedJob is a job on a user's saved list. Expired is set once the
+// posting is no longer active at its source, and SalaryChanged once its
+// salary differs from when the user saved it.
+type SavedJob struct {
+	Job            `bson:",inline"`
+	Expired        bool   `json:"expired" bson:"-"`
+	SalaryChanged  bool   `json:"salaryChanged" bson:"-"`
+	PreviousSalary string `json:"previousSalary,omitempty" bson:"-"`
 }
 
 type UserInteraction struct {
@@ -42,10 +122,55 @@ type JobsResponse struct {
 type JobFilter struct {
 	Search          string   `form:"search"`
 	Skills          []string `form:"skills"`
-	ExperienceLevel string   `form:"experienceLevel"`
+	ExperienceLevel string   `form:"experienceLevel"` // a seniority level
+	EmploymentType  string   `form:"employmentType"`
 	SalaryMin       int      `form:"salaryMin"`
 	SalaryMax       int      `form:"salaryMax"`
 	Source          string   `form:"source"`
 	Page            int      `form:"page,default=1"`
 	Limit           int      `form:"limit,default=20"`
+
+	// Where the candidate can work from; see location.NewPreference
+	Remote   string `form:"remote"`
+	Country  string `form:"country"`
+	Region   string `form:"region"`
+	Timezone string `form:"timezone"`
+}
+
+// Validate checks the filter's seniority, employment type and location.
+func (f *JobFilter) Validate() error {
+	if f.ExperienceLevel != "" && !IsSeniority(f.ExperienceLevel) {
+		return errors.New("experienceLevel must be intern, junior, mid, senior, staff or lead")
+	}
+	if f.EmploymentType != "" && !IsEmploymentType(f.EmploymentType) {
+		return errors.New("employmentType must be full-time, contract, part-time or freelance")
+	}
+	_, err := f.LocationPreference()
+	return err
+}
+
+// LocationPreference validates the filter's location fields.
+func (f *JobFilter) LocationPreference() (location.Preference, error) {
+	return location.NewPreference(f.Remote, f.Country, f.Region, f.Timezone)
+}
+
+type JobStats struct {
+	ActiveJobs           int64            `json:"activeJobs"`
+	InactiveJobs         int64            `json:"inactiveJobs"`
+	JobsBySource         []SourceJobCount `json:"jobsBySource"`
+	InteractionsByAction map[string]int64 `json:"interactionsByAction"`
+}
+
+type SourceJobCount struct {
+	Source string `json:"source" bson:"_id"`
+	Active int64  `json:"active" bson:"active"`
+	Total  int64  `json:"total" bson:"total"`
+}
+
+// SourceSummary is an enabled job source as listed to users.
+type SourceSummary struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	LogoURL     string `json:"logoUrl,omitempty"`
+	JobCount    int64  `json:"jobCount"`
 }
@@ -3,9 +3,13 @@ package jobs
 import (
 	"context"
 	"errors"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/hiresense/backend/internal/config"
+	"github.com/hiresense/backend/internal/location"
+	"github.com/hiresense/backend/internal/skills"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -19,35 +23,21 @@ var (
 type Repository struct {
 	jobs         *mongo.Collection
 	interactions *mongo.Collection
+	revisions    *mongo.Collection
 }
 
 func NewRepository() *Repository {
 	return &Repository{
 		jobs:         config.GetCollection("jobs"),
 		interactions: config.GetCollection("user_interactions"),
+		revisions:    config.GetCollection("job_revisions"),
 	}
 }
 
 func (r *Repository) FindAll(ctx context.Context, filter *JobFilter) (*JobsResponse, error) {
-	query := bson.M{"isActive": true}
-
-	// Search filter
-	if filter.Search != "" {
-		query["$or"] = []bson.M{
-			{"title": bson.M{"$regex": filter.Search, "$options": "i"}},
-			{"company": bson.M{"$regex": filter.Search, "$options": "i"}},
-			{"description": bson.M{"$regex": filter.Search, "$options": "i"}},
-		}
-	}
-
-	// Skills filter
-	if len(filter.Skills) > 0 {
-		query["skills"] = bson.M{"$in": filter.Skills}
-	}
-
-	// Source filter
-	if filter.Source != "" {
-		query["source"] = filter.Source
+	query, err := filter.query()
+	if err != nil {
+		return nil, err
 	}
 
 	// Count total
@@ -94,6 +84,123 @@ func (r *Repository) FindAll(ctx context.Context, filter *JobFilter) (*JobsRespo
 	}, nil
 }
 
+// Each calls fn with every job matching filter, newest first, ignoring
+// its pagination. It stops at the first error fn returns.
+func (r *Repository) Each(ctx context.Context, filter *JobFilter, fn func(*Job) error) error {
+	query, err := filter.query()
+	if err != nil {
+		return err
+	}
+	cursor, err := r.jobs.Find(ctx, query, options.Find().SetSort(bson.M{"postedAt": -1}))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var job Job
+		if err := cursor.Decode(&job); err != nil {
+			return err
+		}
+		if err := fn(&job); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// query builds the MongoDB filter for the active jobs matching f.
+func (f *JobFilter) query() (bson.M, error) {
+	query := bson.M{"isActive": true}
+
+	// Show each cross-source duplicate once, unless the caller asked for a
+	// particular source's own postings
+	if f.Source == "" {
+		query["duplicateOf"] = bson.M{"$exists": false}
+	}
+
+	// Search filter
+	if f.Search != "" {
+		query["$or"] = []bson.M{
+			{"title": bson.M{"$regex": f.Search, "$options": "i"}},
+			{"company": bson.M{"$regex": f.Search, "$options": "i"}},
+			{"description": bson.M{"$regex": f.Search, "$options": "i"}},
+		}
+	}
+
+	// Skills filter, matching aliases, skills below a category and any
+	// spelling stored before normalization
+	if len(f.Skills) > 0 {
+		terms := skills.Expand(f.Skills)
+		terms = append(terms, f.Skills...)
+		patterns := make([]interface{}, 0, len(terms))
+		for _, term := range terms {
+			if term = strings.TrimSpace(term); term != "" {
+				patterns = append(patterns, primitive.Regex{Pattern: "^" + regexp.QuoteMeta(term) + "$", Options: "i"})
+			}
+		}
+		query["skills"] = bson.M{"$in": patterns}
+	}
+
+	// Source filter
+	if f.Source != "" {
+		query["source"] = f.Source
+	}
+
+	// Jobs whose seniority or employment type could not be inferred never
+	// match these
+	if f.ExperienceLevel != "" {
+		query["seniority"] = f.ExperienceLevel
+	}
+	if f.EmploymentType != "" {
+		query["employmentType"] = f.EmploymentType
+	}
+
+	// Salary filters compare annual USD amounts; jobs without a parsed
+	// salary never match
+	if f.SalaryMin > 0 {
+		query["salaryInfo.maxUsd"] = bson.M{"$gte": f.SalaryMin}
+	}
+	if f.SalaryMax > 0 {
+		query["salaryInfo.minUsd"] = bson.M{"$lte": f.SalaryMax}
+	}
+
+	// Location filters
+	pref, err := f.LocationPreference()
+	if err != nil {
+		return nil, err
+	}
+	if clauses := locationClauses(pref); len(clauses) > 0 {
+		query["$and"] = clauses
+	}
+	return query, nil
+}
+
+// locationClauses mirror location.Preference.Allows as MongoDB filters.
+func locationClauses(pref location.Preference) []bson.M {
+	if pref.IsZero() {
+		return nil
+	}
+	clauses := []bson.M{{"locationInfo": bson.M{"$ne": nil}}}
+	if codes, regions := pref.Places(); len(codes) > 0 || len(regions) > 0 {
+		places := []bson.M{{"locationInfo.worldwide": true}}
+		if len(codes) > 0 {
+			places = append(places, bson.M{"locationInfo.countries": bson.M{"$in": codes}})
+		}
+		if len(regions) > 0 {
+			places = append(places, bson.M{"locationInfo.regions": bson.M{"$in": regions}})
+		}
+		clauses = append(clauses, bson.M{"$or": places})
+	}
+	if pref.Offset != nil {
+		clauses = append(clauses, bson.M{"$or": []bson.M{
+			{"locationInfo.timezones": nil},
+			{"locationInfo.timezones.from": bson.M{"$lte": *pref.Offset}, "locationInfo.timezones.to": bson.M{"$gte": *pref.Offset}},
+		}})
+	}
+	return clauses
+}
+
 func (r *Repository) FindByID(ctx context.Context, id string) (*Job, error) {
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
@@ -108,49 +215,274 @@ func (r *Repository) FindByID(ctx context.Context, id string) (*Job, error) {
 	return &job, nil
 }
 
+// UpsertJob stores one job like BulkUpsert, so a changed posting keeps
+// its previous version.
 func (r *Repository) UpsertJob(ctx context.Context, job *Job) error {
-	filter := bson.M{
-		"sourceId": job.SourceID,
-		"source":   job.Source,
+	result, err := r.BulkUpsert(ctx, []Job{*job})
+	if err != nil {
+		return err
+	}
+	if len(result.Errors) > 0 {
+		return result.Errors[0]
 	}
+	return nil
+}
 
-	update := bson.M{
-		"$set": job,
-		"$setOnInsert": bson.M{
-			"scrapedAt": time.Now(),
+// upsertUpdateDoc replaces a job's scraped fields, keeping the time it
+// was first scraped.
+func upsertUpdateDoc(job *Job, now time.Time) bson.M {
+	markSeen(job, now)
+	onInsert := bson.M{"scrapedAt": now}
+	if job.PostedAt.IsZero() {
+		// Left out of $set by omitempty; new jobs without a posting date
+		// use when they were first seen
+		onInsert["postedAt"] = now
+	}
+	return bson.M{
+		"$set":         job,
+		"$setOnInsert": onInsert,
+		"$unset": bson.M{
+			"expiredAt": "",
 		},
 	}
+}
 
-	opts := options.Update().SetUpsert(true)
-	_, err := r.jobs.UpdateOne(ctx, filter, update, opts)
-	return err
+// BulkUpsert stores jobs with one hash lookup and one unordered bulk
+// write per batch.
+func (r *Repository) BulkUpsert(ctx context.Context, jobs []Job) (*BulkResult, error) {
+	return bulkUpsert(ctx, r, jobs)
 }
 
-func (r *Repository) BulkUpsert(ctx context.Context, jobs []Job) (int, int, error) {
-	var added, updated int
+// keysQuery matches the jobs with the given keys.
+func keysQuery(keys []jobKey) bson.M {
+	bySource := make(map[string][]string)
+	for _, key := range keys {
+		bySource[key.source] = append(bySource[key.source], key.sourceID)
+	}
+	clauses := make([]bson.M, 0, len(bySource))
+	for source, ids := range bySource {
+		clauses = append(clauses, bson.M{"source": source, "sourceId": bson.M{"$in": ids}})
+	}
+	return bson.M{"$or": clauses}
+}
 
-	for _, job := range jobs {
-		filter := bson.M{
-			"sourceId": job.SourceID,
-			"source":   job.Source,
+func (r *Repository) contentHashes(ctx context.Context, keys []jobKey) (map[jobKey]string, error) {
+	opts := options.Find().SetProjection(bson.M{"source": 1, "sourceId": 1, "contentHash": 1})
+	cursor, err := r.jobs.Find(ctx, keysQuery(keys), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	hashes := make(map[jobKey]string, len(keys))
+	for cursor.Next(ctx) {
+		var stored struct {
+			Source      string `bson:"source"`
+			SourceID    string `bson:"sourceId"`
+			ContentHash string `bson:"contentHash"`
+		}
+		if err := cursor.Decode(&stored); err != nil {
+			return nil, err
+		}
+		hashes[jobKey{stored.Source, stored.SourceID}] = stored.ContentHash
+	}
+	return hashes, cursor.Err()
+}
+
+func (r *Repository) stored(ctx context.Context, keys []jobKey) (map[jobKey]*Job, error) {
+	opts := options.Find().SetProjection(bson.M{"minHash": 0, "descriptionText": 0, "descriptionMarkdown": 0})
+	cursor, err := r.jobs.Find(ctx, keysQuery(keys), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	stored := make(map[jobKey]*Job, len(keys))
+	for cursor.Next(ctx) {
+		var job Job
+		if err := cursor.Decode(&job); err != nil {
+			return nil, err
 		}
+		stored[jobKey{job.Source, job.SourceID}] = &job
+	}
+	return stored, cursor.Err()
+}
+
+func (r *Repository) saveRevisions(ctx context.Context, revisions []JobRevision) error {
+	docs := make([]interface{}, len(revisions))
+	for i := range revisions {
+		docs[i] = revisions[i]
+	}
+	_, err := r.revisions.InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+	return err
+}
+
+// History returns the changes to a job's posting, newest first.
+func (r *Repository) History(ctx context.Context, id string) (*JobHistory, error) {
+	job, err := r.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := r.revisions.Find(ctx, bson.M{"jobId": job.ID}, options.Find().SetSort(bson.M{"revisedAt": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
 
-		var existing Job
-		err := r.jobs.FindOne(ctx, filter).Decode(&existing)
-		isNew := errors.Is(err, mongo.ErrNoDocuments)
+	var revisions []JobRevision
+	if err := cursor.All(ctx, &revisions); err != nil {
+		return nil, err
+	}
+	return buildHistory(job, revisions), nil
+}
 
-		if err := r.UpsertJob(ctx, &job); err != nil {
+func (r *Repository) write(ctx context.Context, ops []upsertOp, now time.Time) (map[int]string, error) {
+	models := make([]mongo.WriteModel, len(ops))
+	for i, op := range ops {
+		filter := bson.M{"source": op.key.source, "sourceId": op.key.sourceID}
+
+		if op.kind == upsertTouch {
+			models[i] = mongo.NewUpdateOneModel().
+				SetFilter(filter).
+				SetUpdate(bson.M{
+					"$set":   bson.M{"isActive": true, "missedRuns": 0, "lastSeenAt": now},
+					"$unset": bson.M{"expiredAt": ""},
+				})
 			continue
 		}
+		models[i] = mongo.NewUpdateOneModel().
+			SetFilter(filter).
+			SetUpdate(upsertUpdateDoc(op.job, now)).
+			SetUpsert(true)
+
+		// Jobs stored before dates were tracked may have a zero postedAt;
+		// backfill it with when they were first scraped
+		if op.kind == upsertUpdate && op.job.PostedAt.IsZero() {
+			models = append(models, mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"source": op.key.source, "sourceId": op.key.sourceID, "postedAt": bson.M{"$lt": time.Unix(0, 0)}}).
+				SetUpdate(mongo.Pipeline{{{Key: "$set", Value: bson.M{"postedAt": "$scrapedAt"}}}}))
+		}
+	}
+
+	_, err := r.jobs.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+
+	var bulkErr mongo.BulkWriteException
+	if errors.As(err, &bulkErr) && bulkErr.WriteConcernError == nil {
+		failed := make(map[int]string, len(bulkErr.WriteErrors))
+		for _, we := range bulkErr.WriteErrors {
+			if we.Index < len(ops) {
+				failed[we.Index] = we.Message
+			}
+		}
+		return failed, nil
+	}
+	return nil, err
+}
+
+// ExpireMissing is called after a successful scrape of source that started
+// at runStartedAt. Every active job from the source that the run did not
+// see counts one more missed run; jobs that missed maxMissedRuns runs or
+// were last seen more than maxAge ago are deactivated.
+func (r *Repository) ExpireMissing(ctx context.Context, source string, runStartedAt time.Time, maxMissedRuns int, maxAge time.Duration) (int64, error) {
+	missing := bson.M{
+		"source":     source,
+		"isActive":   true,
+		"lastSeenAt": bson.M{"$not": bson.M{"$gte": runStartedAt}},
+	}
+
+	if _, err := r.jobs.UpdateMany(ctx, missing, bson.M{"$inc": bson.M{"missedRuns": 1}}); err != nil {
+		return 0, err
+	}
+
+	var stale []bson.M
+	if maxMissedRuns > 0 {
+		stale = append(stale, bson.M{"missedRuns": bson.M{"$gte": maxMissedRuns}})
+	}
+	if maxAge > 0 {
+		stale = append(stale, bson.M{"lastSeenAt": bson.M{"$lt": time.Now().Add(-maxAge)}})
+	}
+	if len(stale) == 0 {
+		return 0, nil
+	}
+	missing["$or"] = stale
+
+	return r.expire(ctx, missing)
+}
+
+// ExpirePastValidThrough deactivates jobs from source whose validThrough
+// date has passed.
+func (r *Repository) ExpirePastValidThrough(ctx context.Context, source string) (int64, error) {
+	return r.expire(ctx, bson.M{
+		"source":       source,
+		"isActive":     true,
+		"validThrough": bson.M{"$lt": time.Now()},
+	})
+}
+
+func (r *Repository) expire(ctx context.Context, filter bson.M) (int64, error) {
+	result, err := r.jobs.UpdateMany(ctx, filter, bson.M{
+		"$set": bson.M{"isActive": false, "expiredAt": time.Now()},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+// ClusterDuplicates re-clusters every dedup key touched since the given
+// time, by jobs that were scraped or expired, and returns how many jobs
+// changed cluster.
+func (r *Repository) ClusterDuplicates(ctx context.Context, since time.Time) (int, error) {
+	keys, err := r.jobs.Distinct(ctx, "dedupKey", bson.M{
+		"dedupKey": bson.M{"$nin": bson.A{"", nil}},
+		"$or": []bson.M{
+			{"lastSeenAt": bson.M{"$gte": since}},
+			{"expiredAt": bson.M{"$gte": since}},
+		},
+	})
+	if err != nil || len(keys) == 0 {
+		return 0, err
+	}
+
+	opts := options.Find().SetProjection(bson.M{
+		"source": 1, "url": 1, "postedAt": 1, "isActive": 1,
+		"dedupKey": 1, "minHash": 1, "duplicateOf": 1, "alternates": 1,
+	})
+	cursor, err := r.jobs.Find(ctx, bson.M{"dedupKey": bson.M{"$in": keys}}, opts)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var candidates []Job
+	if err := cursor.All(ctx, &candidates); err != nil {
+		return 0, err
+	}
 
-		if isNew {
-			added++
-		} else {
-			updated++
+	updates := clusterDuplicates(candidates)
+	if len(updates) == 0 {
+		return 0, nil
+	}
+
+	models := make([]mongo.WriteModel, len(updates))
+	for i, u := range updates {
+		var update bson.M
+		switch {
+		case u.duplicateOf != nil:
+			update = bson.M{"$set": bson.M{"duplicateOf": u.duplicateOf}, "$unset": bson.M{"alternates": ""}}
+		case len(u.alternates) > 0:
+			update = bson.M{"$set": bson.M{"alternates": u.alternates}, "$unset": bson.M{"duplicateOf": ""}}
+		default:
+			update = bson.M{"$unset": bson.M{"duplicateOf": "", "alternates": ""}}
 		}
+		models[i] = mongo.NewUpdateOneModel().SetFilter(bson.M{"_id": u.id}).SetUpdate(update)
 	}
 
-	return added, updated, nil
+	if _, err := r.jobs.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+		return 0, err
+	}
+	return len(updates), nil
 }
 
 func (r *Repository) SaveJob(ctx context.Context, userID, jobID string) error {
@@ -195,7 +527,7 @@ func (r *Repository) HideJob(ctx context.Context, userID, jobID string) error {
 	return err
 }
 
-func (r *Repository) GetSavedJobs(ctx context.Context, userID string) ([]Job, error) {
+func (r *Repository) GetSavedJobs(ctx context.Context, userID string) ([]SavedJob, error) {
 	userOID, _ := primitive.ObjectIDFromHex(userID)
 
 	// Get saved job IDs
@@ -219,24 +551,117 @@ func (r *Repository) GetSavedJobs(ctx context.Context, userID string) ([]Job, er
 	}
 
 	if len(jobIDs) == 0 {
-		return []Job{}, nil
+		return []SavedJob{}, nil
 	}
 
-	// Get jobs
+	// Get jobs, including ones that have since expired
 	jobCursor, err := r.jobs.Find(ctx, bson.M{"_id": bson.M{"$in": jobIDs}})
 	if err != nil {
 		return nil, err
 	}
 	defer jobCursor.Close(ctx)
 
-	var jobs []Job
+	var jobs []SavedJob
 	if err := jobCursor.All(ctx, &jobs); err != nil {
 		return nil, err
 	}
+	for i := range jobs {
+		jobs[i].Expired = !jobs[i].IsActive
+	}
+
+	// Compare salaries with the versions replaced since each was saved
+	savedAt := make(map[primitive.ObjectID]time.Time, len(interactions))
+	for _, interaction := range interactions {
+		savedAt[interaction.JobID] = interaction.Timestamp
+	}
+	revCursor, err := r.revisions.Find(ctx, bson.M{
+		"jobId":  bson.M{"$in": jobIDs},
+		"fields": bson.M{"$in": bson.A{"salary", "salaryInfo"}},
+	}, options.Find().SetSort(bson.M{"revisedAt": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer revCursor.Close(ctx)
+
+	var revisions []JobRevision
+	if err := revCursor.All(ctx, &revisions); err != nil {
+		return nil, err
+	}
+	markSalaryChanges(jobs, savedAt, revisions)
 
 	return jobs, nil
 }
 
+// Stats aggregates job and interaction counts for the admin dashboard.
+func (r *Repository) Stats(ctx context.Context) (*JobStats, error) {
+	stats := &JobStats{
+		JobsBySource:         []SourceJobCount{},
+		InteractionsByAction: map[string]int64{},
+	}
+
+	cursor, err := r.jobs.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{
+			"_id":    "$source",
+			"total":  bson.M{"$sum": 1},
+			"active": bson.M{"$sum": bson.M{"$cond": bson.A{"$isActive", 1, 0}}},
+		}}},
+		{{Key: "$sort", Value: bson.M{"total": -1}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := cursor.All(ctx, &stats.JobsBySource); err != nil {
+		return nil, err
+	}
+	for _, src := range stats.JobsBySource {
+		stats.ActiveJobs += src.Active
+		stats.InactiveJobs += src.Total - src.Active
+	}
+
+	cursor, err = r.interactions.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{"_id": "$action", "count": bson.M{"$sum": 1}}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var actions []struct {
+		Action string `bson:"_id"`
+		Count  int64  `bson:"count"`
+	}
+	if err := cursor.All(ctx, &actions); err != nil {
+		return nil, err
+	}
+	for _, a := range actions {
+		stats.InteractionsByAction[a.Action] = a.Count
+	}
+
+	return stats, nil
+}
+
+// ActiveCountsBySource counts the active jobs of each source.
+func (r *Repository) ActiveCountsBySource(ctx context.Context) (map[string]int64, error) {
+	cursor, err := r.jobs.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"isActive": true}}},
+		{{Key: "$group", Value: bson.M{"_id": "$source", "count": bson.M{"$sum": 1}}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var counts []struct {
+		Source string `bson:"_id"`
+		Count  int64  `bson:"count"`
+	}
+	if err := cursor.All(ctx, &counts); err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]int64, len(counts))
+	for _, c := range counts {
+		result[c.Source] = c.Count
+	}
+	return result, nil
+}
+
 func (r *Repository) GetHiddenJobIDs(ctx context.Context, userID string) ([]primitive.ObjectID, error) {
 	userOID, _ := primitive.ObjectIDFromHex(userID)
 
@@ -0,0 +1,170 @@
+package jobs
+
+import (
+	"reflect"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// JobVersion is what a job's source said about it at one point in time.
+// Fields derived from it, such as locationInfo or the USD conversion of
+// its salary, are left out.
+type JobVersion struct {
+	Title          string        `json:"title" bson:"title"`
+	Company        string        `json:"company" bson:"company"`
+	Description    string        `json:"description" bson:"description"`
+	Skills         []string      `json:"skills" bson:"skills"`
+	Salary         string        `json:"salary" bson:"salary"`
+	SalaryInfo     *PostedSalary `json:"salaryInfo,omitempty" bson:"salaryInfo,omitempty"`
+	Location       string        `json:"location" bson:"location"`
+	URL            string        `json:"url" bson:"url"`
+	PostedAt       time.Time     `json:"postedAt" bson:"postedAt"`
+	ValidThrough   *time.Time    `json:"validThrough,omitempty" bson:"validThrough,omitempty"`
+	Seniority      string        `json:"seniority,omitempty" bson:"seniority"`
+	EmploymentType string        `json:"employmentType,omitempty" bson:"employmentType"`
+}
+
+// Version returns the job's current version.
+func (j *Job) Version() JobVersion {
+	return JobVersion{
+		Title:          j.Title,
+		Company:        j.Company,
+		Description:    j.Description,
+		Skills:         j.Skills,
+		Salary:         j.Salary,
+		SalaryInfo:     j.SalaryInfo.Posted(),
+		Location:       j.Location,
+		URL:            j.URL,
+		PostedAt:       j.PostedAt.UTC(),
+		ValidThrough:   j.ValidThrough,
+		Seniority:      j.Seniority,
+		EmploymentType: j.EmploymentType,
+	}
+}
+
+// JobRevision is a version of a job that was replaced when its source
+// changed the posting. Fields names what changed in the replacement.
+type JobRevision struct {
+	ID        primitive.ObjectID `json:"_id,omitempty" bson:"_id,omitempty"`
+	JobID     primitive.ObjectID `json:"jobId" bson:"jobId"`
+	Version   JobVersion         `json:"version" bson:"version"`
+	Fields    []string           `json:"fields" bson:"fields"`
+	RevisedAt time.Time          `json:"revisedAt" bson:"revisedAt"`
+}
+
+// FieldChange is one field that differs between two versions of a job.
+type FieldChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+// versionFields lists the fields Diff compares, by their JSON names.
+var versionFields = []struct {
+	name string
+	get  func(v *JobVersion) interface{}
+}{
+	{"title", func(v *JobVersion) interface{} { return v.Title }},
+	{"company", func(v *JobVersion) interface{} { return v.Company }},
+	{"description", func(v *JobVersion) interface{} { return v.Description }},
+	{"skills", func(v *JobVersion) interface{} { return v.Skills }},
+	{"salary", func(v *JobVersion) interface{} { return v.Salary }},
+	{"salaryInfo", func(v *JobVersion) interface{} { return v.SalaryInfo }},
+	{"location", func(v *JobVersion) interface{} { return v.Location }},
+	{"url", func(v *JobVersion) interface{} { return v.URL }},
+	{"postedAt", func(v *JobVersion) interface{} { return v.PostedAt.UTC() }},
+	{"validThrough", func(v *JobVersion) interface{} {
+		if v.ValidThrough == nil {
+			return nil
+		}
+		return v.ValidThrough.UTC()
+	}},
+	{"seniority", func(v *JobVersion) interface{} { return v.Seniority }},
+	{"employmentType", func(v *JobVersion) interface{} { return v.EmploymentType }},
+}
+
+// Diff returns the fields that differ between two versions of a job.
+// Empty and missing lists are the same.
+func Diff(from, to JobVersion) []FieldChange {
+	changes := []FieldChange{}
+	for _, f := range versionFields {
+		a, b := f.get(&from), f.get(&to)
+		if reflect.DeepEqual(a, b) || (isEmptyList(a) && isEmptyList(b)) {
+			continue
+		}
+		changes = append(changes, FieldChange{Field: f.name, From: a, To: b})
+	}
+	return changes
+}
+
+func isEmptyList(v interface{}) bool {
+	list, ok := v.([]string)
+	return ok && len(list) == 0
+}
+
+func changedFields(changes []FieldChange) []string {
+	fields := make([]string, len(changes))
+	for i, c := range changes {
+		fields[i] = c.Field
+	}
+	return fields
+}
+
+// JobHistory lists the changes to a job's posting, newest first.
+type JobHistory struct {
+	JobID       primitive.ObjectID `json:"jobId"`
+	FirstSeenAt time.Time          `json:"firstSeenAt"`
+	Changes     []JobChange        `json:"changes"`
+}
+
+// JobChange is one update to a posting.
+type JobChange struct {
+	ChangedAt time.Time     `json:"changedAt"`
+	Fields    []FieldChange `json:"fields"`
+}
+
+// buildHistory diffs each revision of job against the version that
+// replaced it.
+func buildHistory(job *Job, revisions []JobRevision) *JobHistory {
+	sort.Slice(revisions, func(i, j int) bool { return revisions[i].RevisedAt.Before(revisions[j].RevisedAt) })
+
+	history := &JobHistory{JobID: job.ID, FirstSeenAt: job.ScrapedAt, Changes: []JobChange{}}
+	for i := len(revisions) - 1; i >= 0; i-- {
+		next := job.Version()
+		if i+1 < len(revisions) {
+			next = revisions[i+1].Version
+		}
+		history.Changes = append(history.Changes, JobChange{
+			ChangedAt: revisions[i].RevisedAt,
+			Fields:    Diff(revisions[i].Version, next),
+		})
+	}
+	return history
+}
+
+// markSalaryChanges flags saved jobs whose salary differs from when they
+// were saved. revisions must be oldest first; the first one replaced
+// after a job was saved holds the salary the user saw.
+func markSalaryChanges(saved []SavedJob, savedAt map[primitive.ObjectID]time.Time, revisions []JobRevision) {
+	seen := make(map[primitive.ObjectID]JobVersion)
+	for _, rev := range revisions {
+		if _, ok := seen[rev.JobID]; ok || !rev.RevisedAt.After(savedAt[rev.JobID]) {
+			continue
+		}
+		seen[rev.JobID] = rev.Version
+	}
+
+	for i := range saved {
+		job := &saved[i]
+		before, ok := seen[job.ID]
+		if !ok {
+			continue
+		}
+		if before.Salary != job.Salary || !reflect.DeepEqual(before.SalaryInfo, job.SalaryInfo.Posted()) {
+			job.SalaryChanged = true
+			job.PreviousSalary = before.Salary
+		}
+	}
+}
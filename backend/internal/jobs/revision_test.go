@@ -0,0 +1,126 @@
+package jobs
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestBulkUpsertRecordsRevisions(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+
+	list := sampleJobs(2)
+	list[0].Salary = "USD 90000"
+	if _, err := repo.BulkUpsert(ctx, list); err != nil {
+		t.Fatal(err)
+	}
+	original := repo.Jobs()[0]
+
+	// Unchanged jobs make no revisions
+	if _, err := repo.BulkUpsert(ctx, list); err != nil {
+		t.Fatal(err)
+	}
+	if revs := repo.Revisions(); len(revs) != 0 {
+		t.Fatalf("revisions after an unchanged run = %+v", revs)
+	}
+
+	list[0].Salary = "USD 100000"
+	list[0].Title = "Senior Engineer 0"
+	if _, err := repo.BulkUpsert(ctx, list); err != nil {
+		t.Fatal(err)
+	}
+	revs := repo.Revisions()
+	if len(revs) != 1 {
+		t.Fatalf("revisions = %+v, want 1", revs)
+	}
+	if revs[0].JobID != original.ID || revs[0].Version.Salary != "USD 90000" || revs[0].Version.Title != "Engineer 0" {
+		t.Errorf("revision = %+v, want the original version", revs[0])
+	}
+	if !reflect.DeepEqual(revs[0].Fields, []string{"title", "salary"}) {
+		t.Errorf("fields = %v", revs[0].Fields)
+	}
+}
+
+func TestBuildHistory(t *testing.T) {
+	firstSeen := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+	job := &Job{ID: primitive.NewObjectID(), Title: "Staff Engineer", Salary: "USD 120000", ScrapedAt: firstSeen}
+	revisions := []JobRevision{
+		{Version: JobVersion{Title: "Senior Engineer", Salary: "USD 120000"}, RevisedAt: firstSeen.Add(48 * time.Hour)},
+		{Version: JobVersion{Title: "Senior Engineer", Salary: "USD 100000"}, RevisedAt: firstSeen.Add(24 * time.Hour)},
+	}
+
+	history := buildHistory(job, revisions)
+	if history.FirstSeenAt != firstSeen || len(history.Changes) != 2 {
+		t.Fatalf("history = %+v", history)
+	}
+	want := []JobChange{
+		{ChangedAt: firstSeen.Add(48 * time.Hour), Fields: []FieldChange{{Field: "title", From: "Senior Engineer", To: "Staff Engineer"}}},
+		{ChangedAt: firstSeen.Add(24 * time.Hour), Fields: []FieldChange{{Field: "salary", From: "USD 100000", To: "USD 120000"}}},
+	}
+	if !reflect.DeepEqual(history.Changes, want) {
+		t.Errorf("changes = %+v, want %+v", history.Changes, want)
+	}
+}
+
+func TestMarkSalaryChanges(t *testing.T) {
+	savedAt := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+	raised, unchanged, reverted := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+
+	saved := []SavedJob{
+		{Job: Job{ID: raised, Salary: "USD 120000"}},
+		{Job: Job{ID: unchanged, Salary: "USD 90000"}},
+		{Job: Job{ID: reverted, Salary: "USD 80000"}},
+	}
+	revisions := []JobRevision{
+		// Replaced before the user saved the job, so they saw 100000
+		{JobID: raised, Version: JobVersion{Salary: "USD 95000"}, RevisedAt: savedAt.Add(-time.Hour)},
+		{JobID: raised, Version: JobVersion{Salary: "USD 100000"}, RevisedAt: savedAt.Add(time.Hour)},
+		{JobID: reverted, Version: JobVersion{Salary: "USD 80000"}, RevisedAt: savedAt.Add(time.Hour)},
+		{JobID: reverted, Version: JobVersion{Salary: "USD 70000"}, RevisedAt: savedAt.Add(2 * time.Hour)},
+	}
+	markSalaryChanges(saved, map[primitive.ObjectID]time.Time{raised: savedAt, unchanged: savedAt, reverted: savedAt}, revisions)
+
+	if !saved[0].SalaryChanged || saved[0].PreviousSalary != "USD 100000" {
+		t.Errorf("raised = %+v", saved[0])
+	}
+	if saved[1].SalaryChanged || saved[2].SalaryChanged {
+		t.Errorf("unchanged = %v, reverted = %v, want neither marked", saved[1].SalaryChanged, saved[2].SalaryChanged)
+	}
+}
+
+func TestExchangeRateChangeIsNotARevision(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+
+	list := sampleJobs(1)
+	list[0].Salary = "EUR 70000 per year"
+	list[0].SalaryInfo = &SalaryInfo{Min: 70000, Max: 70000, Currency: "EUR", Period: PeriodYear, MinUSD: 75600, MaxUSD: 75600}
+	if _, err := repo.BulkUpsert(ctx, list); err != nil {
+		t.Fatal(err)
+	}
+	savedAt := time.Now()
+
+	// Only the USD conversion moves, which is not a change to the posting
+	list[0].SalaryInfo = &SalaryInfo{Min: 70000, Max: 70000, Currency: "EUR", Period: PeriodYear, MinUSD: 77000, MaxUSD: 77000}
+	result, err := repo.BulkUpsert(ctx, list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Unchanged != 1 || len(repo.Revisions()) != 0 {
+		t.Errorf("result = %+v, revisions = %+v, want the job unchanged", result, repo.Revisions())
+	}
+
+	stored := repo.Jobs()[0]
+	saved := []SavedJob{{Job: stored}}
+	revisions := []JobRevision{{JobID: stored.ID, Version: sampleJobs(1)[0].Version(), RevisedAt: savedAt.Add(time.Hour)}}
+	revisions[0].Version.Salary = stored.Salary
+	revisions[0].Version.SalaryInfo = &PostedSalary{Min: 70000, Max: 70000, Currency: "EUR", Period: PeriodYear}
+	markSalaryChanges(saved, map[primitive.ObjectID]time.Time{stored.ID: savedAt}, revisions)
+	if saved[0].SalaryChanged {
+		t.Errorf("saved job marked as changed: %+v", saved[0])
+	}
+}
@@ -0,0 +1,284 @@
+package jobs
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// SalaryInfo is a salary range as posted, plus its bounds converted to an
+// annual amount in US dollars so jobs can be filtered and compared.
+type SalaryInfo struct {
+	Min      float64 `json:"min" bson:"min"`
+	Max      float64 `json:"max" bson:"max"`
+	Currency string  `json:"currency" bson:"currency"`
+	Period   string  `json:"period" bson:"period"`
+	MinUSD   int     `json:"minUsd" bson:"minUsd"`
+	MaxUSD   int     `json:"maxUsd" bson:"maxUsd"`
+}
+
+// PostedSalary is a SalaryInfo without its USD conversion, which changes
+// with exchange rates rather than with the posting.
+type PostedSalary struct {
+	Min      float64 `json:"min" bson:"min"`
+	Max      float64 `json:"max" bson:"max"`
+	Currency string  `json:"currency" bson:"currency"`
+	Period   string  `json:"period" bson:"period"`
+}
+
+// Posted returns the range as posted, or nil for a nil SalaryInfo.
+func (s *SalaryInfo) Posted() *PostedSalary {
+	if s == nil {
+		return nil
+	}
+	return &PostedSalary{Min: s.Min, Max: s.Max, Currency: s.Currency, Period: s.Period}
+}
+
+// Salary periods
+const (
+	PeriodHour  = "hour"
+	PeriodDay   = "day"
+	PeriodWeek  = "week"
+	PeriodMonth = "month"
+	PeriodYear  = "year"
+)
+
+var periodsPerYear = map[string]float64{
+	PeriodHour:  2080,
+	PeriodDay:   260,
+	PeriodWeek:  52,
+	PeriodMonth: 12,
+	PeriodYear:  1,
+}
+
+// USD per unit of each currency. Rates only need to be roughly right to
+// rank and filter salaries; override them with SetFXRates.
+var (
+	fxMu    sync.RWMutex
+	fxRates = map[string]float64{
+		"USD": 1,
+		"EUR": 1.08,
+		"GBP": 1.27,
+		"CAD": 0.73,
+		"AUD": 0.66,
+		"NZD": 0.60,
+		"CHF": 1.13,
+		"SEK": 0.095,
+		"NOK": 0.092,
+		"DKK": 0.145,
+		"PLN": 0.25,
+		"INR": 0.012,
+		"JPY": 0.0067,
+		"SGD": 0.74,
+		"BRL": 0.18,
+		"MXN": 0.055,
+	}
+)
+
+// SetFXRates adds or replaces USD conversion rates, keyed by ISO 4217 code.
+func SetFXRates(rates map[string]float64) {
+	fxMu.Lock()
+	defer fxMu.Unlock()
+	for code, rate := range rates {
+		if rate > 0 {
+			fxRates[strings.ToUpper(code)] = rate
+		}
+	}
+}
+
+func usdRate(currency string) (float64, bool) {
+	fxMu.RLock()
+	defer fxMu.RUnlock()
+	rate, ok := fxRates[currency]
+	return rate, ok
+}
+
+// NewSalaryInfo builds a SalaryInfo from already structured bounds. A zero
+// max means a single figure. It returns nil when the currency has no known
+// rate or the bounds are not positive.
+func NewSalaryInfo(low, high float64, currency, period string) *SalaryInfo {
+	if high == 0 {
+		high = low
+	}
+	if low == 0 {
+		low = high
+	}
+	if low <= 0 {
+		return nil
+	}
+	if high < low {
+		low, high = high, low
+	}
+
+	currency = strings.ToUpper(currency)
+	if currency == "" {
+		currency = "USD"
+	}
+	rate, ok := usdRate(currency)
+	if !ok {
+		return nil
+	}
+	if _, ok := periodsPerYear[period]; !ok {
+		period = guessPeriod(high)
+	}
+
+	annual := periodsPerYear[period] * rate
+	return &SalaryInfo{
+		Min:      low,
+		Max:      high,
+		Currency: currency,
+		Period:   period,
+		MinUSD:   int(math.Round(low * annual)),
+		MaxUSD:   int(math.Round(high * annual)),
+	}
+}
+
+// guessPeriod picks a period for a salary that does not state one.
+func guessPeriod(amount float64) string {
+	switch {
+	case amount < 1000:
+		return PeriodHour
+	case amount < 20000:
+		return PeriodMonth
+	default:
+		return PeriodYear
+	}
+}
+
+var (
+	salaryAmountPattern = regexp.MustCompile(`(?i)(\d[\d,.]*)\s*([km])?\b`)
+	salaryCodePattern   = regexp.MustCompile(`\b[A-Z]{3}\b`)
+	salaryRangePattern  = regexp.MustCompile(`(?i)^(-|–|—|to)$`)
+
+	// Periods count only as rates, as in "per hour", "/yr" or "monthly",
+	// so "4 day week" or "6 month contract" is not read as a salary period
+	salaryPeriodPatterns = []struct {
+		period  string
+		pattern *regexp.Regexp
+	}{
+		{PeriodHour, ratePattern(`hour|hr|h`, `hourly`)},
+		{PeriodDay, ratePattern(`day|d`, `daily`)},
+		{PeriodWeek, ratePattern(`week|wk`, `weekly`)},
+		{PeriodMonth, ratePattern(`month|mo|mth`, `monthly`)},
+		{PeriodYear, ratePattern(`year|yr|annum`, `yearly|annually|\bp\.?a\.?\b`)},
+	}
+
+	// US retirement plans look like amounts with a k suffix
+	retirementPlanPattern = regexp.MustCompile(`(?i)\b40[13]\s*\(?[kb]\)?`)
+
+	salarySymbols = []struct{ symbol, currency string }{
+		{"CA$", "CAD"}, {"C$", "CAD"}, {"AU$", "AUD"}, {"A$", "AUD"}, {"NZ$", "NZD"},
+		{"$", "USD"}, {"€", "EUR"}, {"£", "GBP"}, {"₹", "INR"}, {"¥", "JPY"},
+	}
+)
+
+func ratePattern(units, adverbs string) *regexp.Regexp {
+	return regexp.MustCompile(`(?i)((\bper|\ban?|/)\s*(` + units + `)\b|\b(` + adverbs + `))`)
+}
+
+// ParseSalary extracts a salary range from free text such as
+// "$120k - $150k", "€70,000/yr" or "60-80 USD/hour". A bare number is
+// not a salary: the text must name a currency, use a k or m suffix or
+// state a period. It returns nil when no salary is found.
+func ParseSalary(text string) *SalaryInfo {
+	text = retirementPlanPattern.ReplaceAllString(text, "")
+	matches := salaryAmountPattern.FindAllStringSubmatchIndex(text, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	low, lowMult := parseSalaryAmount(text, matches[0])
+	high, highMult := low, lowMult
+	if len(matches) > 1 {
+		between := strings.ToUpper(text[matches[0][1]:matches[1][0]])
+		if salaryRangePattern.MatchString(strings.TrimSpace(stripCurrency(between))) {
+			high, highMult = parseSalaryAmount(text, matches[1])
+		}
+	}
+
+	// "120-150k" applies the multiplier to both ends
+	if lowMult == 1 && highMult > 1 && low <= high {
+		low *= highMult
+	} else {
+		low *= lowMult
+	}
+	high *= highMult
+
+	period := ""
+	for _, p := range salaryPeriodPatterns {
+		if p.pattern.MatchString(text) {
+			period = p.period
+			break
+		}
+	}
+
+	currency, named := salaryCurrency(text)
+	if !named && lowMult == 1 && highMult == 1 && period == "" {
+		return nil
+	}
+	return NewSalaryInfo(low, high, currency, period)
+}
+
+// parseSalaryAmount reads one amount match, treating "," and "." followed
+// by three digits as thousands separators.
+func parseSalaryAmount(text string, match []int) (float64, float64) {
+	digits := text[match[2]:match[3]]
+	digits = strings.TrimRight(digits, ".,")
+
+	var b strings.Builder
+	for i, part := range strings.FieldsFunc(digits, func(r rune) bool { return r == ',' || r == '.' }) {
+		if i > 0 {
+			if len(part) != 3 {
+				b.WriteByte('.')
+			}
+		}
+		b.WriteString(part)
+	}
+	amount, _ := strconv.ParseFloat(b.String(), 64)
+
+	mult := 1.0
+	if match[4] >= 0 {
+		switch strings.ToLower(text[match[4]:match[5]]) {
+		case "k":
+			mult = 1000
+		case "m":
+			mult = 1000000
+		}
+	}
+	return amount, mult
+}
+
+// salaryCurrency returns the currency text names, or USD and false when
+// it names none.
+func salaryCurrency(text string) (string, bool) {
+	for _, code := range salaryCodePattern.FindAllString(strings.ToUpper(text), -1) {
+		if _, ok := usdRate(code); ok {
+			return code, true
+		}
+	}
+	for _, s := range salarySymbols {
+		if strings.Contains(text, s.symbol) {
+			return s.currency, true
+		}
+	}
+	return "USD", false
+}
+
+func stripCurrency(text string) string {
+	for _, s := range salarySymbols {
+		text = strings.ReplaceAll(text, s.symbol, "")
+	}
+	return salaryCodePattern.ReplaceAllString(text, "")
+}
+
+// String renders the range the way scrapers format salaries, e.g.
+// "USD 120000 - 150000 per year".
+func (s *SalaryInfo) String() string {
+	if s.Min == s.Max {
+		return fmt.Sprintf("%s %.0f per %s", s.Currency, s.Min, s.Period)
+	}
+	return fmt.Sprintf("%s %.0f - %.0f per %s", s.Currency, s.Min, s.Max, s.Period)
+}
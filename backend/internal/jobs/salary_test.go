@@ -0,0 +1,49 @@
+package jobs
+
+import "testing"
+
+func TestParseSalary(t *testing.T) {
+	tests := []struct {
+		text     string
+		min, max float64
+		currency string
+		period   string
+		minUSD   int
+	}{
+		{"$120k - $150k", 120000, 150000, "USD", PeriodYear, 120000},
+		{"€70,000/yr", 70000, 70000, "EUR", PeriodYear, 75600},
+		{"60-80 USD/hour", 60, 80, "USD", PeriodHour, 124800},
+		{"USD 150000 - 190000 per year", 150000, 190000, "USD", PeriodYear, 150000},
+		{"£45.000 to £55.000 p.a.", 45000, 55000, "GBP", PeriodYear, 57150},
+		{"120-150k + equity", 120000, 150000, "USD", PeriodYear, 120000},
+		{"CA$9,000 per month", 9000, 9000, "CAD", PeriodMonth, 78840},
+		{"45-60 an hour", 45, 60, "USD", PeriodHour, 93600},
+		{"$140k, 401(k) match, 4 day week", 140000, 140000, "USD", PeriodYear, 140000},
+	}
+
+	for _, tt := range tests {
+		got := ParseSalary(tt.text)
+		if got == nil {
+			t.Errorf("ParseSalary(%q) = nil", tt.text)
+			continue
+		}
+		if got.Min != tt.min || got.Max != tt.max || got.Currency != tt.currency || got.Period != tt.period || got.MinUSD != tt.minUSD {
+			t.Errorf("ParseSalary(%q) = %+v", tt.text, got)
+		}
+	}
+
+	// Numbers without a currency, suffix or period are not salaries
+	for _, text := range []string{"", "Competitive", "DOE", "401(k) match", "Benefits: 401k", "Competitive, 4 day week", "10 - 15 LPA", "6 month contract"} {
+		if got := ParseSalary(text); got != nil {
+			t.Errorf("ParseSalary(%q) = %+v, want nil", text, got)
+		}
+	}
+}
+
+func TestSetFXRates(t *testing.T) {
+	SetFXRates(map[string]float64{"zar": 0.055})
+	got := ParseSalary("ZAR 600000")
+	if got == nil || got.Currency != "ZAR" || got.MinUSD != 33000 {
+		t.Errorf("got %+v", got)
+	}
+}
@@ -0,0 +1,144 @@
+package location
+
+// Regions
+const (
+	Europe       = "Europe"
+	EMEA         = "EMEA"
+	NorthAmerica = "North America"
+	LatAm        = "Latin America"
+	Americas     = "Americas"
+	APAC         = "APAC"
+	Asia         = "Asia"
+	Africa       = "Africa"
+	Oceania      = "Oceania"
+	MiddleEast   = "Middle East"
+)
+
+var regionNames = map[string]string{
+	"europe":          Europe,
+	"european union":  Europe,
+	"eu":              Europe,
+	"emea":            EMEA,
+	"north america":   NorthAmerica,
+	"latam":           LatAm,
+	"latin america":   LatAm,
+	"south america":   LatAm,
+	"central america": LatAm,
+	"americas":        Americas,
+	"apac":            APAC,
+	"asia pacific":    APAC,
+	"asia":            Asia,
+	"africa":          Africa,
+	"oceania":         Oceania,
+	"middle east":     MiddleEast,
+	"mena":            MiddleEast,
+	"anz":             Oceania,
+}
+
+type country struct {
+	code    string
+	regions []string
+}
+
+var (
+	eu       = []string{Europe, EMEA}
+	na       = []string{NorthAmerica, Americas}
+	latam    = []string{LatAm, Americas}
+	apac     = []string{APAC, Asia}
+	oceania  = []string{Oceania, APAC}
+	africa   = []string{Africa, EMEA}
+	mideast  = []string{MiddleEast, EMEA}
+	westAsia = []string{MiddleEast, Asia, EMEA}
+)
+
+// countries maps lowercase names and aliases to ISO 3166-1 alpha-2 codes
+// and the regions the country belongs to.
+var countries = map[string]country{
+	"united states": {"US", na}, "usa": {"US", na}, "u.s.": {"US", na}, "u.s.a.": {"US", na},
+	"canada": {"CA", na},
+	"mexico": {"MX", latam}, "brazil": {"BR", latam}, "argentina": {"AR", latam},
+	"colombia": {"CO", latam}, "chile": {"CL", latam}, "peru": {"PE", latam},
+	"uruguay": {"UY", latam}, "costa rica": {"CR", latam},
+	"united kingdom": {"GB", eu}, "uk": {"GB", eu}, "great britain": {"GB", eu},
+	"england": {"GB", eu}, "scotland": {"GB", eu}, "ireland": {"IE", eu},
+	"portugal": {"PT", eu}, "spain": {"ES", eu}, "france": {"FR", eu},
+	"germany": {"DE", eu}, "netherlands": {"NL", eu}, "the netherlands": {"NL", eu},
+	"belgium": {"BE", eu}, "switzerland": {"CH", eu}, "austria": {"AT", eu},
+	"italy": {"IT", eu}, "poland": {"PL", eu}, "czech republic": {"CZ", eu},
+	"czechia": {"CZ", eu}, "sweden": {"SE", eu}, "norway": {"NO", eu},
+	"denmark": {"DK", eu}, "finland": {"FI", eu}, "estonia": {"EE", eu},
+	"latvia": {"LV", eu}, "lithuania": {"LT", eu}, "romania": {"RO", eu},
+	"bulgaria": {"BG", eu}, "greece": {"GR", eu}, "hungary": {"HU", eu},
+	"croatia": {"HR", eu}, "serbia": {"RS", eu}, "slovakia": {"SK", eu},
+	"slovenia": {"SI", eu}, "ukraine": {"UA", eu},
+	"turkey": {"TR", westAsia}, "israel": {"IL", westAsia},
+	"united arab emirates": {"AE", mideast}, "uae": {"AE", mideast},
+	"india": {"IN", apac}, "pakistan": {"PK", apac}, "bangladesh": {"BD", apac},
+	"singapore": {"SG", apac}, "philippines": {"PH", apac}, "indonesia": {"ID", apac},
+	"vietnam": {"VN", apac}, "malaysia": {"MY", apac}, "thailand": {"TH", apac},
+	"japan": {"JP", apac}, "south korea": {"KR", apac}, "china": {"CN", apac},
+	"australia": {"AU", oceania}, "new zealand": {"NZ", oceania},
+	"south africa": {"ZA", africa}, "nigeria": {"NG", africa}, "kenya": {"KE", africa},
+	"egypt": {"EG", africa}, "ghana": {"GH", africa}, "morocco": {"MA", africa},
+
+	// Cities that often stand in for their country
+	"new york": {"US", na}, "nyc": {"US", na}, "san francisco": {"US", na},
+	"sf": {"US", na}, "bay area": {"US", na}, "seattle": {"US", na},
+	"los angeles": {"US", na}, "austin": {"US", na}, "chicago": {"US", na},
+	"boston": {"US", na}, "toronto": {"CA", na}, "vancouver": {"CA", na},
+	"montreal": {"CA", na}, "london": {"GB", eu}, "berlin": {"DE", eu},
+	"munich": {"DE", eu}, "paris": {"FR", eu}, "amsterdam": {"NL", eu},
+	"dublin": {"IE", eu}, "lisbon": {"PT", eu}, "madrid": {"ES", eu},
+	"barcelona": {"ES", eu}, "stockholm": {"SE", eu}, "warsaw": {"PL", eu},
+	"bangalore": {"IN", apac}, "bengaluru": {"IN", apac}, "sydney": {"AU", oceania},
+	"melbourne": {"AU", oceania}, "tel aviv": {"IL", westAsia},
+}
+
+// usStates are the two-letter codes seen in "Austin, TX" style locations.
+var usStates = map[string]bool{
+	"AL": true, "AK": true, "AZ": true, "AR": true, "CA": true, "CO": true, "CT": true, "DE": true,
+	"FL": true, "GA": true, "HI": true, "ID": true, "IL": true, "IN": true, "IA": true, "KS": true,
+	"KY": true, "LA": true, "ME": true, "MD": true, "MA": true, "MI": true, "MN": true, "MS": true,
+	"MO": true, "MT": true, "NE": true, "NV": true, "NH": true, "NJ": true, "NM": true, "NY": true,
+	"NC": true, "ND": true, "OH": true, "OK": true, "OR": true, "PA": true, "RI": true, "SC": true,
+	"SD": true, "TN": true, "TX": true, "UT": true, "VT": true, "VA": true, "WA": true, "WV": true,
+	"WI": true, "WY": true, "DC": true,
+}
+
+// regionParents are the wider regions that contain a region, so someone
+// in Europe can also take a job open to EMEA.
+var regionParents = map[string][]string{
+	Europe:       {EMEA},
+	Africa:       {EMEA},
+	MiddleEast:   {EMEA},
+	NorthAmerica: {Americas},
+	LatAm:        {Americas},
+	Asia:         {APAC},
+	Oceania:      {APAC},
+}
+
+// timezoneNames are standard-time UTC offsets in hours.
+var timezoneNames = map[string]float64{
+	"pst": -8, "pdt": -7, "pacific time": -8, "mst": -7, "mountain time": -7,
+	"cst": -6, "central time": -6, "est": -5, "edt": -4, "eastern time": -5,
+	"wet": 0, "bst": 1, "cet": 1, "cest": 2, "eet": 2, "ist": 5.5, "sgt": 8,
+	"jst": 9, "aest": 10,
+}
+
+// fillerWords say nothing about where a job can be done from. A location
+// made only of these, like "Remote", is open to anyone.
+var fillerWords = map[string]bool{
+	"remote": true, "fully": true, "work": true, "from": true, "home": true,
+	"wfh": true, "distributed": true, "only": true, "friendly": true, "ok": true,
+	"position": true, "role": true, "team": true, "in": true, "the": true,
+	"and": true, "or": true, "to": true, "of": true, "based": true, "within": true,
+	"between": true, "hours": true, "hour": true, "h": true, "hrs": true,
+	"timezone": true, "timezones": true, "time": true, "zone": true, "zones": true,
+	"overlap": true, "plus": true, "minus": true, "any": true, "location": true,
+}
+
+// worldwideWords say a job is open to anyone.
+var worldwideWords = map[string]bool{
+	"worldwide": true, "world wide": true, "anywhere": true, "global": true,
+	"globally": true, "international": true, "anywhere in the world": true,
+}
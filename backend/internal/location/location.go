@@ -0,0 +1,173 @@
+// Package location parses free-form job locations such as "USA Only" or
+// "Europe, UTC-1 to UTC+3" into where a job can be done from, and matches
+// them against where a candidate is.
+package location
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// defaultOverlap is how many hours either side of a single timezone, as in
+// "CET" or "UTC+2", a job is assumed to accept.
+const defaultOverlap = 2
+
+// maxPhraseWords is the longest place or timezone name, in words.
+const maxPhraseWords = 4
+
+var (
+	offsetPattern    = regexp.MustCompile(`\b(?:utc|gmt)\s*(?:([+\-−–])\s*(\d{1,2})(?:[:.]?(\d{2}))?)?`)
+	plusMinusPattern = regexp.MustCompile(`(?:±|\+/-|\+-|plus or minus)\s*(\d{1,2})\s*(?:h|hrs?|hours?)?\b`)
+	statePattern     = regexp.MustCompile(`,\s*([A-Z]{2})\b`)
+	wordPattern      = regexp.MustCompile(`[\p{L}\p{N}]+`)
+	dottedCountries  = strings.NewReplacer("U.S.A.", " USA ", "U.S.", " USA ")
+)
+
+// Info is where a job can be done from. Worldwide jobs have no country or
+// region restriction; Timezones, when set, still limits them to candidates
+// whose working hours overlap.
+type Info struct {
+	Worldwide bool     `json:"worldwide" bson:"worldwide"`
+	Countries []string `json:"countries,omitempty" bson:"countries"`
+	Regions   []string `json:"regions,omitempty" bson:"regions"`
+	Timezones *Window  `json:"timezones,omitempty" bson:"timezones"`
+}
+
+// Window is a range of UTC offsets, in hours.
+type Window struct {
+	From float64 `json:"from" bson:"from"`
+	To   float64 `json:"to" bson:"to"`
+}
+
+// Contains reports whether offset falls inside the window.
+func (w *Window) Contains(offset float64) bool {
+	return offset >= w.From && offset <= w.To
+}
+
+// Parse reads a job location. Countries are ISO 3166-1 alpha-2 codes and
+// regions are the names in this package, e.g. Europe or LatAm. A location
+// naming no place, like "Remote" or "Anywhere", is worldwide; one naming
+// only places this package does not know, like "Springfield", is not.
+// Parse returns nil for an empty location.
+func Parse(s string) *Info {
+	s = strings.TrimSpace(dottedCountries.Replace(s))
+	if s == "" {
+		return nil
+	}
+
+	info := &Info{}
+	seen := make(map[string]bool)
+	addCountry := func(code string) {
+		if !seen["c:"+code] {
+			seen["c:"+code] = true
+			info.Countries = append(info.Countries, code)
+		}
+	}
+	addRegion := func(region string) {
+		if !seen["r:"+region] {
+			seen["r:"+region] = true
+			info.Regions = append(info.Regions, region)
+		}
+	}
+
+	// "US" is only a country in capitals; "us" is an ordinary word
+	for _, w := range wordPattern.FindAllString(s, -1) {
+		if w == "US" {
+			addCountry("US")
+		}
+	}
+	for _, m := range statePattern.FindAllStringSubmatch(s, -1) {
+		if usStates[m[1]] {
+			addCountry("US")
+		}
+	}
+
+	lower := strings.ToLower(s)
+	var offsets []float64
+	for _, m := range offsetPattern.FindAllStringSubmatch(lower, -1) {
+		offsets = append(offsets, parseOffsetMatch(m))
+	}
+
+	worldwide, unknown := false, false
+	words := wordPattern.FindAllString(offsetPattern.ReplaceAllString(lower, " "), -1)
+	for i := 0; i < len(words); {
+		n := min(maxPhraseWords, len(words)-i)
+		for ; n > 0; n-- {
+			phrase := strings.Join(words[i:i+n], " ")
+			if c, ok := countries[phrase]; ok {
+				addCountry(c.code)
+			} else if r, ok := regionNames[phrase]; ok {
+				addRegion(r)
+			} else if offset, ok := timezoneNames[phrase]; ok {
+				offsets = append(offsets, offset)
+			} else if worldwideWords[phrase] {
+				worldwide = true
+			} else {
+				continue
+			}
+			break
+		}
+		if n == 0 {
+			if !fillerWords[words[i]] && !isNumber(words[i]) {
+				unknown = true
+			}
+			n = 1
+		}
+		i += n
+	}
+
+	if len(info.Countries) == 0 && len(info.Regions) == 0 {
+		info.Worldwide = worldwide || !unknown
+	}
+	info.Timezones = window(offsets, lower)
+	return info
+}
+
+// window spans the offsets a location mentions. A single offset is widened
+// by a "± N hours" in the location, or by defaultOverlap.
+func window(offsets []float64, lower string) *Window {
+	if len(offsets) == 0 {
+		return nil
+	}
+	w := &Window{From: offsets[0], To: offsets[0]}
+	for _, o := range offsets[1:] {
+		w.From = min(w.From, o)
+		w.To = max(w.To, o)
+	}
+
+	spread := 0.0
+	if m := plusMinusPattern.FindStringSubmatch(lower); m != nil {
+		spread, _ = strconv.ParseFloat(m[1], 64)
+	} else if w.From == w.To {
+		spread = defaultOverlap
+	}
+	w.From -= spread
+	w.To += spread
+	return w
+}
+
+func parseOffsetMatch(m []string) float64 {
+	if m[2] == "" {
+		return 0
+	}
+	hours, _ := strconv.ParseFloat(m[2], 64)
+	if m[3] != "" {
+		minutes, _ := strconv.ParseFloat(m[3], 64)
+		hours += minutes / 60
+	}
+	if m[1] != "+" {
+		hours = -hours
+	}
+	return hours
+}
+
+func isNumber(s string) bool {
+	for _, r := range s {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,85 @@
+package location
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want *Info
+	}{
+		{"", nil},
+		{"Remote", &Info{Worldwide: true}},
+		{"Anywhere in the World", &Info{Worldwide: true}},
+		{"Worldwide", &Info{Worldwide: true}},
+		{"USA Only", &Info{Countries: []string{"US"}}},
+		{"Remote US", &Info{Countries: []string{"US"}}},
+		{"REMOTE (US, Canada)", &Info{Countries: []string{"US", "CA"}}},
+		{"Austin, TX", &Info{Countries: []string{"US"}}},
+		{"Hybrid (NYC)", &Info{Countries: []string{"US"}}},
+		{"Remote - Europe", &Info{Regions: []string{Europe}}},
+		{"LATAM or EMEA", &Info{Regions: []string{LatAm, EMEA}}},
+		{"Europe, UTC-1 to UTC+3", &Info{Regions: []string{Europe}, Timezones: &Window{From: -1, To: 3}}},
+		{"Remote, GMT+5:30", &Info{Worldwide: true, Timezones: &Window{From: 3.5, To: 7.5}}},
+		{"Remote (EST ± 3 hours)", &Info{Worldwide: true, Timezones: &Window{From: -8, To: -2}}},
+		{"ONSITE", &Info{}},
+	}
+	for _, tt := range tests {
+		if got := Parse(tt.in); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Parse(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestPreferenceAllows(t *testing.T) {
+	germany, err := NewPreference(RemoteRegion, "Germany", "", "UTC+1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	global, _ := NewPreference(RemoteGlobal, "", "", "")
+
+	tests := []struct {
+		location string
+		pref     Preference
+		want     bool
+	}{
+		{"Worldwide", germany, true},
+		{"Remote - Europe", germany, true},
+		{"EMEA", germany, true},
+		{"Berlin", germany, true},
+		{"USA Only", germany, false},
+		{"Remote, UTC-8 to UTC-5", germany, false},
+		{"Worldwide", global, true},
+		{"Remote - Europe", global, true},
+		{"USA Only", global, true},
+		{"USA Only", Preference{}, true},
+	}
+	for _, tt := range tests {
+		if got := tt.pref.Allows(Parse(tt.location)); got != tt.want {
+			t.Errorf("%+v.Allows(%q) = %v, want %v", tt.pref, tt.location, got, tt.want)
+		}
+	}
+}
+
+func TestNewPreference(t *testing.T) {
+	p, err := NewPreference("", "de", "europe", "+05:30")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Country != "DE" || p.Region != Europe || p.Offset == nil || *p.Offset != 5.5 {
+		t.Errorf("NewPreference = %+v", p)
+	}
+
+	for _, args := range [][4]string{
+		{"office", "", "", ""},
+		{"", "Atlantis", "", ""},
+		{"", "", "Narnia", ""},
+		{"", "", "", "UTC+banana"},
+	} {
+		if _, err := NewPreference(args[0], args[1], args[2], args[3]); err == nil {
+			t.Errorf("NewPreference%q: expected an error", args)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package location
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Remote preferences, as stored on user profiles. A global candidate can
+// work from anywhere, so it does not narrow the jobs they see.
+const (
+	RemoteGlobal = "global"
+	RemoteRegion = "region"
+)
+
+// Preference is where a candidate can work from. The zero value accepts
+// every job.
+type Preference struct {
+	// Country is an ISO 3166-1 alpha-2 code.
+	Country string
+	Region  string
+	// Offset is the candidate's UTC offset in hours.
+	Offset *float64
+}
+
+// NewPreference validates the remote, country, region and timezone filters
+// shared by job search and user profiles. Country accepts a code or a name,
+// region a region name, and timezone a UTC offset like "UTC+2" or an IANA
+// name like "Europe/Berlin".
+func NewPreference(remote, country, region, timezone string) (Preference, error) {
+	var p Preference
+	switch remote {
+	case "", RemoteGlobal, RemoteRegion:
+	default:
+		return p, fmt.Errorf("unknown remote preference %q", remote)
+	}
+	if country != "" {
+		if p.Country = CountryCode(country); p.Country == "" {
+			return p, fmt.Errorf("unknown country %q", country)
+		}
+	}
+	if region != "" {
+		if p.Region = RegionName(region); p.Region == "" {
+			return p, fmt.Errorf("unknown region %q", region)
+		}
+	}
+	if timezone != "" {
+		offset, err := ParseOffset(timezone)
+		if err != nil {
+			return p, err
+		}
+		p.Offset = &offset
+	}
+	return p, nil
+}
+
+// IsZero reports whether p accepts every job.
+func (p Preference) IsZero() bool {
+	return p.Country == "" && p.Region == "" && p.Offset == nil
+}
+
+// Places are the country codes and regions a job may be restricted to and
+// still be open to the candidate.
+func (p Preference) Places() (codes, regions []string) {
+	if p.Country != "" {
+		codes = append(codes, p.Country)
+		for _, r := range RegionsOf(p.Country) {
+			regions = appendRegion(regions, r)
+		}
+	}
+	if p.Region != "" {
+		regions = appendRegion(regions, p.Region)
+	}
+	return codes, regions
+}
+
+// Allows reports whether the candidate can take a job at info. Jobs without
+// parsed location info are only allowed by the zero Preference.
+func (p Preference) Allows(info *Info) bool {
+	if p.IsZero() {
+		return true
+	}
+	if info == nil {
+		return false
+	}
+	if codes, regions := p.Places(); !info.Worldwide && (len(codes) > 0 || len(regions) > 0) {
+		if !overlaps(info.Countries, codes) && !overlaps(info.Regions, regions) {
+			return false
+		}
+	}
+	if p.Offset != nil && info.Timezones != nil && !info.Timezones.Contains(*p.Offset) {
+		return false
+	}
+	return true
+}
+
+// CountryCode returns the ISO code for a country code or name, or "" if
+// the country is unknown.
+func CountryCode(s string) string {
+	s = strings.TrimSpace(s)
+	if c, ok := countries[strings.ToLower(s)]; ok {
+		return c.code
+	}
+	code := strings.ToUpper(s)
+	for _, c := range countries {
+		if c.code == code {
+			return code
+		}
+	}
+	return ""
+}
+
+// RegionsOf returns the regions a country belongs to.
+func RegionsOf(code string) []string {
+	for _, c := range countries {
+		if c.code == code {
+			return c.regions
+		}
+	}
+	return nil
+}
+
+// RegionName returns the canonical name of a region, or "" if it is
+// unknown.
+func RegionName(s string) string {
+	return regionNames[strings.ToLower(strings.TrimSpace(s))]
+}
+
+// ParseOffset reads a UTC offset in hours from "UTC+2", "+05:30", "GMT",
+// "CET" or an IANA timezone name. IANA names use the zone's current offset.
+func ParseOffset(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	lower := strings.ToLower(s)
+	if m := offsetPattern.FindStringSubmatch(lower); m != nil && m[0] == lower {
+		return parseOffsetMatch(m), nil
+	}
+	if m := offsetPattern.FindStringSubmatch("utc" + lower); m != nil && m[0] == "utc"+lower && m[2] != "" {
+		return parseOffsetMatch(m), nil
+	}
+	if offset, ok := timezoneNames[lower]; ok {
+		return offset, nil
+	}
+	if loc, err := time.LoadLocation(s); err == nil && s != "" && s != "Local" {
+		_, seconds := time.Now().In(loc).Zone()
+		return float64(seconds) / 3600, nil
+	}
+	return 0, fmt.Errorf("unknown timezone %q", s)
+}
+
+// appendRegion adds a region and the wider regions containing it.
+func appendRegion(regions []string, region string) []string {
+	for _, r := range append([]string{region}, regionParents[region]...) {
+		if !contains(regions, r) {
+			regions = append(regions, r)
+		}
+	}
+	return regions
+}
+
+func overlaps(a, b []string) bool {
+	for _, s := range a {
+		if contains(b, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
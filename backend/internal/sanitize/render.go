@@ -0,0 +1,212 @@
+package sanitize
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var (
+	whitespacePattern = regexp.MustCompile(`\s+`)
+	blankLinesPattern = regexp.MustCompile(`\n{3,}`)
+	markdownEscaper   = strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "`", "\\`", "<", `\<`)
+)
+
+var headingLevels = map[atom.Atom]int{
+	atom.H1: 1, atom.H2: 2, atom.H3: 3, atom.H4: 4, atom.H5: 5, atom.H6: 6,
+}
+
+type list struct {
+	ordered bool
+	items   int
+}
+
+// renderer writes an HTML tree as plain text or Markdown.
+type renderer struct {
+	markdown bool
+	b        strings.Builder
+	pre      int
+	lists    []list
+}
+
+func (r *renderer) String() string {
+	lines := strings.Split(r.b.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	out := blankLinesPattern.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+	return strings.TrimSpace(out)
+}
+
+func (r *renderer) atLineStart() bool {
+	s := r.b.String()
+	return s == "" || strings.HasSuffix(s, "\n")
+}
+
+func (r *renderer) block() { r.b.WriteString("\n\n") }
+
+func (r *renderer) node(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		r.text(n.Data)
+	case html.ElementNode:
+		if !droppedTags[n.DataAtom] {
+			r.element(n)
+		}
+	}
+}
+
+func (r *renderer) children(n *html.Node) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		r.node(c)
+	}
+}
+
+func (r *renderer) text(s string) {
+	if r.pre > 0 {
+		r.b.WriteString(s)
+		return
+	}
+	s = whitespacePattern.ReplaceAllString(s, " ")
+	if r.atLineStart() {
+		s = strings.TrimLeft(s, " ")
+	}
+	if r.markdown {
+		s = markdownEscaper.Replace(s)
+	}
+	r.b.WriteString(s)
+}
+
+// inline renders n's children on their own so markers hug the text, as
+// in "**bold**" rather than "** bold **".
+func (r *renderer) inline(n *html.Node) string {
+	sub := &renderer{markdown: r.markdown, pre: r.pre, lists: r.lists}
+	sub.children(n)
+	return strings.TrimSpace(whitespacePattern.ReplaceAllString(sub.b.String(), " "))
+}
+
+func (r *renderer) wrap(n *html.Node, marker string) {
+	if !r.markdown {
+		r.children(n)
+		return
+	}
+	if s := r.inline(n); s != "" {
+		r.b.WriteString(marker + s + marker)
+	}
+}
+
+func (r *renderer) element(n *html.Node) {
+	switch n.DataAtom {
+	case atom.P, atom.Div:
+		r.block()
+		r.children(n)
+		r.block()
+
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		r.block()
+		if r.markdown {
+			r.b.WriteString(strings.Repeat("#", headingLevels[n.DataAtom]) + " ")
+		}
+		r.b.WriteString(r.inline(n))
+		r.block()
+
+	case atom.Br:
+		r.b.WriteString("\n")
+
+	case atom.Hr:
+		r.block()
+		if r.markdown {
+			r.b.WriteString("---")
+		}
+		r.block()
+
+	case atom.Ul, atom.Ol:
+		r.lists = append(r.lists, list{ordered: n.DataAtom == atom.Ol})
+		r.b.WriteString("\n")
+		r.children(n)
+		r.lists = r.lists[:len(r.lists)-1]
+		if len(r.lists) == 0 {
+			r.block()
+		}
+
+	case atom.Li:
+		if !r.atLineStart() {
+			r.b.WriteString("\n")
+		}
+		marker := "- "
+		if depth := len(r.lists); depth > 0 {
+			current := &r.lists[depth-1]
+			current.items++
+			if current.ordered {
+				marker = strconv.Itoa(current.items) + ". "
+			}
+			r.b.WriteString(strings.Repeat("  ", depth-1))
+		}
+		r.b.WriteString(marker)
+		r.children(n)
+		r.b.WriteString("\n")
+
+	case atom.Strong, atom.B:
+		r.wrap(n, "**")
+
+	case atom.Em, atom.I:
+		r.wrap(n, "_")
+
+	case atom.Code:
+		if r.pre > 0 || !r.markdown {
+			r.children(n)
+			return
+		}
+		sub := &renderer{pre: 1}
+		sub.children(n)
+		if s := strings.TrimSpace(sub.b.String()); s != "" {
+			r.b.WriteString("`" + s + "`")
+		}
+
+	case atom.Pre:
+		r.block()
+		if r.markdown {
+			r.b.WriteString("```\n")
+		}
+		r.pre++
+		r.children(n)
+		r.pre--
+		if r.markdown {
+			r.b.WriteString("\n```")
+		}
+		r.block()
+
+	case atom.A:
+		href := safeHref(n)
+		if !r.markdown || href == "" {
+			r.children(n)
+			return
+		}
+		text := r.inline(n)
+		if text == "" {
+			text = markdownEscaper.Replace(href)
+		}
+		r.b.WriteString("[" + text + "](" + href + ")")
+
+	case atom.Blockquote:
+		sub := &renderer{markdown: r.markdown}
+		sub.children(n)
+		r.block()
+		for i, line := range strings.Split(sub.String(), "\n") {
+			if i > 0 {
+				r.b.WriteString("\n")
+			}
+			if r.markdown {
+				line = strings.TrimRight("> "+line, " ")
+			}
+			r.b.WriteString(line)
+		}
+		r.block()
+
+	default:
+		r.children(n)
+	}
+}
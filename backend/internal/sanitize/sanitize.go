@@ -0,0 +1,148 @@
+// Package sanitize cleans scraped job descriptions. HTML keeps only an
+// allowlist of formatting tags, and Text and Markdown render the same
+// content for consumers that cannot display HTML.
+package sanitize
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// allowedTags are kept, without attributes except a safe href on links.
+// Other tags are unwrapped, keeping their content.
+var allowedTags = map[atom.Atom]bool{
+	atom.P: true, atom.Br: true, atom.Hr: true, atom.Div: true,
+	atom.Ul: true, atom.Ol: true, atom.Li: true,
+	atom.Strong: true, atom.B: true, atom.Em: true, atom.I: true, atom.U: true,
+	atom.A: true, atom.Code: true, atom.Pre: true, atom.Blockquote: true,
+	atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+}
+
+// droppedTags are removed together with everything inside them.
+var droppedTags = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Iframe: true, atom.Object: true,
+	atom.Embed: true, atom.Noscript: true, atom.Template: true, atom.Svg: true,
+	atom.Math: true, atom.Form: true, atom.Input: true, atom.Button: true,
+	atom.Select: true, atom.Textarea: true, atom.Head: true, atom.Title: true,
+	atom.Meta: true, atom.Link: true, atom.Img: true,
+}
+
+var safeSchemes = map[string]bool{"http": true, "https": true, "mailto": true}
+
+var (
+	tagPattern       = regexp.MustCompile(`<[a-zA-Z/!][^>]*>`)
+	paragraphPattern = regexp.MustCompile(`\n\s*\n`)
+)
+
+// parse reads a description as an HTML fragment. Plain text is converted
+// first so its paragraphs and line breaks survive.
+func parse(s string) []*html.Node {
+	if !tagPattern.MatchString(s) {
+		s = textToHTML(s)
+	}
+	nodes, err := html.ParseFragment(strings.NewReader(s), &html.Node{
+		Type: html.ElementNode, Data: "body", DataAtom: atom.Body,
+	})
+	if err != nil {
+		return []*html.Node{{Type: html.TextNode, Data: s}}
+	}
+	return nodes
+}
+
+func textToHTML(s string) string {
+	var b strings.Builder
+	for _, para := range paragraphPattern.Split(strings.TrimSpace(s), -1) {
+		if para = strings.TrimSpace(para); para == "" {
+			continue
+		}
+		b.WriteString("<p>")
+		b.WriteString(strings.ReplaceAll(html.EscapeString(para), "\n", "<br>"))
+		b.WriteString("</p>")
+	}
+	return b.String()
+}
+
+// HTML returns s with everything outside the allowlist removed.
+func HTML(s string) string {
+	var b strings.Builder
+	for _, n := range parse(s) {
+		writeHTML(&b, n)
+	}
+	return strings.TrimSpace(b.String())
+}
+
+func writeHTML(b *strings.Builder, n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		b.WriteString(html.EscapeString(n.Data))
+		return
+	case html.ElementNode:
+	default:
+		return
+	}
+
+	if droppedTags[n.DataAtom] {
+		return
+	}
+	if !allowedTags[n.DataAtom] {
+		writeChildrenHTML(b, n)
+		return
+	}
+
+	b.WriteString("<" + n.Data)
+	if n.DataAtom == atom.A {
+		if href := safeHref(n); href != "" {
+			b.WriteString(` href="` + html.EscapeString(href) + `" rel="nofollow noopener noreferrer"`)
+		}
+	}
+	b.WriteString(">")
+	if n.DataAtom == atom.Br || n.DataAtom == atom.Hr {
+		return
+	}
+	writeChildrenHTML(b, n)
+	b.WriteString("</" + n.Data + ">")
+}
+
+func writeChildrenHTML(b *strings.Builder, n *html.Node) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		writeHTML(b, c)
+	}
+}
+
+// safeHref returns a link's href if it is an absolute http(s) or mailto URL.
+func safeHref(n *html.Node) string {
+	for _, attr := range n.Attr {
+		if attr.Key != "href" {
+			continue
+		}
+		u, err := url.Parse(strings.TrimSpace(attr.Val))
+		if err != nil || !safeSchemes[strings.ToLower(u.Scheme)] {
+			return ""
+		}
+		return u.String()
+	}
+	return ""
+}
+
+// Text returns the description as plain text, with blank lines between
+// paragraphs and "- " before list items.
+func Text(s string) string {
+	r := &renderer{}
+	for _, n := range parse(s) {
+		r.node(n)
+	}
+	return r.String()
+}
+
+// Markdown returns the description as Markdown.
+func Markdown(s string) string {
+	r := &renderer{markdown: true}
+	for _, n := range parse(s) {
+		r.node(n)
+	}
+	return r.String()
+}
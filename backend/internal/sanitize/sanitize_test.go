@@ -0,0 +1,45 @@
+package sanitize
+
+import "testing"
+
+const sample = `<h2>About the role</h2>
+<p onclick="steal()">We build <b>remote-first</b> tools.<script>alert(1)</script></p>
+<ul><li>Go &amp; <em>PostgreSQL</em></li><li>Read <a href="https://example.com/docs" target="_blank">the docs</a></li></ul>
+<p><a href="javascript:alert(1)">click</a> <img src=x onerror=alert(1)><span style="color:red">2*3</span></p>`
+
+func TestHTML(t *testing.T) {
+	got := HTML(sample)
+	want := `<h2>About the role</h2>
+<p>We build <b>remote-first</b> tools.</p>
+<ul><li>Go &amp; <em>PostgreSQL</em></li><li>Read <a href="https://example.com/docs" rel="nofollow noopener noreferrer">the docs</a></li></ul>
+<p><a>click</a> 2*3</p>`
+	if got != want {
+		t.Errorf("HTML =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestText(t *testing.T) {
+	got := Text(sample)
+	want := "About the role\n\nWe build remote-first tools.\n\n- Go & PostgreSQL\n- Read the docs\n\nclick 2*3"
+	if got != want {
+		t.Errorf("Text =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	got := Markdown(sample)
+	want := "## About the role\n\nWe build **remote-first** tools.\n\n- Go & _PostgreSQL_\n- Read [the docs](https://example.com/docs)\n\nclick 2\\*3"
+	if got != want {
+		t.Errorf("Markdown =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestPlainTextInput(t *testing.T) {
+	in := "First paragraph\nsecond line\n\nAnother <3 paragraph"
+	if got, want := HTML(in), "<p>First paragraph<br>second line</p><p>Another &lt;3 paragraph</p>"; got != want {
+		t.Errorf("HTML = %q, want %q", got, want)
+	}
+	if got, want := Text(in), "First paragraph\nsecond line\n\nAnother <3 paragraph"; got != want {
+		t.Errorf("Text = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,140 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const leverFixture = `[
+  {
+    "id": "5ac21346-8e0c-4494-8e7a-3eb92ff77902",
+    "text": "Senior Backend Engineer",
+    "hostedUrl": "https://jobs.lever.co/acme/5ac21346-8e0c-4494-8e7a-3eb92ff77902",
+    "createdAt": 1728900000000,
+    "description": "<div>Build our platform.</div>",
+    "workplaceType": "remote",
+    "categories": {"commitment": "Full-time", "location": "US", "team": "Engineering"},
+    "lists": [{"text": "Requirements", "content": "<li>Go</li><li>PostgreSQL</li>"}],
+    "salaryRange": {"min": 150000, "max": 190000, "currency": "USD", "interval": "per-year-salary"}
+  },
+  {"id": "", "text": "Broken posting"}
+]`
+
+const greenhouseFixture = `{
+  "jobs": [
+    {
+      "id": 4012345,
+      "title": "Product Designer",
+      "absolute_url": "https://boards.greenhouse.io/globex/jobs/4012345",
+      "updated_at": "2024-10-14T10:15:00-04:00",
+      "first_published": "2024-09-30T09:00:00-04:00",
+      "company_name": "Globex",
+      "content": "&lt;p&gt;Design &amp;amp; ship.&lt;/p&gt;",
+      "location": {"name": "Remote - Europe"}
+    }
+  ],
+  "meta": {"total": 1}
+}`
+
+func newBoardsServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/lever/acme", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("mode") != "json" {
+			t.Errorf("lever request without mode=json: %s", r.URL)
+		}
+		w.Write([]byte(leverFixture))
+	})
+	mux.HandleFunc("/greenhouse/globex/jobs", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("content") != "true" {
+			t.Errorf("greenhouse request without content=true: %s", r.URL)
+		}
+		w.Write([]byte(greenhouseFixture))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestLeverCompany(t *testing.T) {
+	tests := []struct{ entry, slug, name string }{
+		{"acme", "acme", "Acme"},
+		{"acme-corp", "acme-corp", "Acme Corp"},
+		{"acme-corp = Acme Corporation", "acme-corp", "Acme Corporation"},
+		{"élan-labs", "élan-labs", "Élan Labs"},
+	}
+	for _, tt := range tests {
+		if slug, name := leverCompany(tt.entry); slug != tt.slug || name != tt.name {
+			t.Errorf("leverCompany(%q) = %q, %q, want %q, %q", tt.entry, slug, name, tt.slug, tt.name)
+		}
+	}
+}
+
+func TestLeverScraper(t *testing.T) {
+	srv := newBoardsServer(t)
+	s := NewLeverScraper([]string{"acme=ACME Inc.", "unknown"})
+	s.client = srv.Client()
+	s.baseURL = srv.URL + "/lever"
+
+	got, err := s.Scrape(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "unknown") {
+		t.Errorf("expected an error for the unknown company, got %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("got %d jobs, want 1", len(got))
+	}
+
+	job := got[0]
+	if job.Source != "Lever" || job.SourceID != "acme/5ac21346-8e0c-4494-8e7a-3eb92ff77902" {
+		t.Errorf("source/sourceId = %q/%q", job.Source, job.SourceID)
+	}
+	if job.Company != "ACME Inc." || job.Title != "Senior Backend Engineer" {
+		t.Errorf("company/title = %q/%q", job.Company, job.Title)
+	}
+	if job.Location != "Remote US" {
+		t.Errorf("location = %q", job.Location)
+	}
+	if job.Salary != "USD 150000 - 190000 per year" {
+		t.Errorf("salary = %q", job.Salary)
+	}
+	if !strings.Contains(job.Description, "<li>Go</li>") {
+		t.Errorf("description is missing the requirement lists: %q", job.Description)
+	}
+	if want := time.UnixMilli(1728900000000).UTC(); !job.PostedAt.Equal(want) {
+		t.Errorf("postedAt = %v, want %v", job.PostedAt, want)
+	}
+}
+
+func TestGreenhouseScraper(t *testing.T) {
+	srv := newBoardsServer(t)
+	s := NewGreenhouseScraper([]string{"globex"})
+	s.client = srv.Client()
+	s.baseURL = srv.URL + "/greenhouse"
+
+	got, err := s.Scrape(context.Background())
+	if err != nil {
+		t.Fatalf("Scrape: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("got %d jobs, want 1", len(got))
+	}
+
+	job := got[0]
+	if job.Source != "Greenhouse" || job.SourceID != "globex/4012345" {
+		t.Errorf("source/sourceId = %q/%q", job.Source, job.SourceID)
+	}
+	if job.Company != "Globex" || job.Location != "Remote - Europe" {
+		t.Errorf("company/location = %q/%q", job.Company, job.Location)
+	}
+	if job.Description != "<p>Design &amp; ship.</p>" {
+		t.Errorf("description = %q", job.Description)
+	}
+	// Posted when first published, not when last edited
+	if want := time.Date(2024, 9, 30, 13, 0, 0, 0, time.UTC); !job.PostedAt.Equal(want) {
+		t.Errorf("postedAt = %v, want %v", job.PostedAt, want)
+	}
+}
@@ -0,0 +1,150 @@
+package scraper
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dateLayouts are the absolute formats sources use for posting dates.
+// Layouts without a zone are read as UTC.
+var dateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02",
+	time.RFC1123Z,
+	time.RFC1123,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	time.RFC822Z,
+	time.RFC822,
+	"January 2, 2006",
+	"Jan 2, 2006",
+	"2 January 2006",
+	"2 Jan 2006",
+}
+
+var relativeDatePattern = regexp.MustCompile(`(?i)\b(a|an|one|\d+)\+?\s*(seconds?|secs?|s|minutes?|mins?|m|hours?|hrs?|h|days?|d|weeks?|wks?|w|months?|mos?|years?|yrs?|y)\s+ago\b`)
+
+var relativeUnits = map[byte]time.Duration{
+	's': time.Second,
+	'h': time.Hour,
+	'd': 24 * time.Hour,
+	'w': 7 * 24 * time.Hour,
+	'y': 365 * 24 * time.Hour,
+}
+
+// earliestDate rules out dates that are really zero values or
+// placeholders, like an epoch of 0.
+var earliestDate = time.Date(1995, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// parseDate reads a date from a JSON value: a string in one of
+// dateLayouts, epoch seconds or milliseconds as a number or string, or a
+// relative date like "3 days ago" or "yesterday". Dates before 1995 are
+// rejected.
+func parseDate(v interface{}) (time.Time, bool) {
+	return parseDateAt(v, time.Now())
+}
+
+// parsePostedDate is parseDate for the date a job was posted, which also
+// rejects dates more than a day in the future.
+func parsePostedDate(v interface{}) (time.Time, bool) {
+	now := time.Now()
+	t, ok := parseDateAt(v, now)
+	if !ok || t.After(now.Add(24*time.Hour)) {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+func parseDateAt(v interface{}, now time.Time) (time.Time, bool) {
+	var t time.Time
+	var ok bool
+	switch val := v.(type) {
+	case float64:
+		t, ok = epochTime(val), true
+	case int64:
+		t, ok = epochTime(float64(val)), true
+	case int:
+		t, ok = epochTime(float64(val)), true
+	case json.Number:
+		f, err := val.Float64()
+		t, ok = epochTime(f), err == nil
+	case string:
+		t, ok = parseDateString(strings.TrimSpace(val), now)
+	}
+	if !ok || t.Before(earliestDate) {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+func parseDateString(s string, now time.Time) (time.Time, bool) {
+	if s == "" {
+		return time.Time{}, false
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return epochTime(f), true
+	}
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC(), true
+		}
+	}
+	return parseRelativeDate(s, now)
+}
+
+// epochTime reads epoch seconds, or milliseconds for values too large to
+// be seconds.
+func epochTime(n float64) time.Time {
+	if n > 1e11 {
+		return time.UnixMilli(int64(n)).UTC()
+	}
+	return time.Unix(int64(n), 0).UTC()
+}
+
+// parseRelativeDate reads dates like "3 days ago". They are rounded down
+// to the day in UTC so that runs on the same day agree on the date.
+func parseRelativeDate(s string, now time.Time) (time.Time, bool) {
+	t, ok := relativeTime(s, now)
+	if !ok {
+		return time.Time{}, false
+	}
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC), true
+}
+
+func relativeTime(s string, now time.Time) (time.Time, bool) {
+	lower := strings.ToLower(s)
+	switch {
+	case strings.Contains(lower, "just now"), strings.Contains(lower, "today"):
+		return now, true
+	case strings.Contains(lower, "yesterday"):
+		return now.AddDate(0, 0, -1), true
+	}
+
+	m := relativeDatePattern.FindStringSubmatch(lower)
+	if m == nil {
+		return time.Time{}, false
+	}
+	n := 1
+	if m[1][0] >= '0' && m[1][0] <= '9' {
+		n, _ = strconv.Atoi(m[1])
+	}
+
+	unit := m[2]
+	switch {
+	case strings.HasPrefix(unit, "mo"):
+		return now.AddDate(0, -n, 0), true
+	case strings.HasPrefix(unit, "m"):
+		return now.Add(-time.Duration(n) * time.Minute), true
+	}
+	return now.Add(-time.Duration(n) * relativeUnits[unit[0]]), true
+}
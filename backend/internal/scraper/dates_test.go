@@ -0,0 +1,57 @@
+package scraper
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	now := time.Date(2024, 10, 15, 12, 0, 0, 0, time.UTC)
+	today := time.Date(2024, 10, 15, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in   interface{}
+		want time.Time
+	}{
+		{"2024-10-01T09:30:00+02:00", time.Date(2024, 10, 1, 7, 30, 0, 0, time.UTC)},
+		{"2024-10-01T09:30:00.123Z", time.Date(2024, 10, 1, 9, 30, 0, 123000000, time.UTC)},
+		{"2024-10-01T09:30:00", time.Date(2024, 10, 1, 9, 30, 0, 0, time.UTC)},
+		{"2024-10-01", time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)},
+		{"Tue, 01 Oct 2024 09:30:00 +0000", time.Date(2024, 10, 1, 9, 30, 0, 0, time.UTC)},
+		{"Tue, 1 Oct 2024 09:30:00 GMT", time.Date(2024, 10, 1, 9, 30, 0, 0, time.UTC)},
+		{"October 1, 2024", time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)},
+		{float64(1727775000), time.Date(2024, 10, 1, 9, 30, 0, 0, time.UTC)},
+		{"1727775000000", time.Date(2024, 10, 1, 9, 30, 0, 0, time.UTC)},
+		{"3 days ago", today.AddDate(0, 0, -3)},
+		{"Posted 2 weeks ago", today.AddDate(0, 0, -14)},
+		{"an hour ago", today},
+		{"30+ days ago", today.AddDate(0, 0, -30)},
+		{"yesterday", today.AddDate(0, 0, -1)},
+	}
+	for _, tt := range tests {
+		got, ok := parseDateAt(tt.in, now)
+		if !ok || !got.Equal(tt.want) {
+			t.Errorf("parseDate(%v) = %v, %v, want %v", tt.in, got, ok, tt.want)
+		}
+	}
+
+	for _, in := range []interface{}{"", "soon", float64(0), "0001-01-01T00:00:00Z", nil} {
+		if got, ok := parseDateAt(in, now); ok {
+			t.Errorf("parseDate(%v) = %v, want no date", in, got)
+		}
+	}
+	if _, ok := parsePostedDate(time.Now().AddDate(0, 1, 0).Format(time.RFC3339)); ok {
+		t.Error("parsePostedDate should reject dates in the future")
+	}
+}
+
+func TestRelativeDateIsStableWithinADay(t *testing.T) {
+	morning := time.Date(2024, 10, 15, 6, 5, 0, 0, time.UTC)
+	evening := time.Date(2024, 10, 15, 22, 40, 0, 0, time.UTC)
+	for _, in := range []string{"3 days ago", "today", "2 hours ago", "1 month ago"} {
+		first, ok1 := parseDateAt(in, morning)
+		second, ok2 := parseDateAt(in, evening)
+		if !ok1 || !ok2 || !first.Equal(second) {
+			t.Errorf("%q: morning run = %v, evening run = %v, want the same date", in, first, second)
+		}
+	}
+}
@@ -0,0 +1,458 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hiresense/backend/internal/jobs"
+	"gopkg.in/yaml.v3"
+)
+
+// Definition declares a JSON job API that can be scraped without writing Go
+// code. Definitions are loaded from YAML or JSON files at startup.
+type Definition struct {
+	Name        string            `json:"name" yaml:"name"`
+	URL         string            `json:"url" yaml:"url"`
+	Headers     map[string]string `json:"headers" yaml:"headers"`
+	JobsPath    string            `json:"jobsPath" yaml:"jobsPath"`
+	Pagination  Pagination        `json:"pagination" yaml:"pagination"`
+	Fields      FieldMappings     `json:"fields" yaml:"fields"`
+	DateLayouts []string          `json:"dateLayouts" yaml:"dateLayouts"`
+}
+
+// Pagination styles:
+//   - "" or "none": a single request
+//   - "page": Param is set to Start, Start+1, ...
+//   - "offset": Param is set to Start, Start+PageSize, ...
+//   - "cursor": Param is set to the value found at CursorPath in the previous response
+//   - "next": the URL found at CursorPath in the previous response is requested next
+type Pagination struct {
+	Type       string `json:"type" yaml:"type"`
+	Param      string `json:"param" yaml:"param"`
+	Start      int    `json:"start" yaml:"start"`
+	PageSize   int    `json:"pageSize" yaml:"pageSize"`
+	SizeParam  string `json:"sizeParam" yaml:"sizeParam"`
+	CursorPath string `json:"cursorPath" yaml:"cursorPath"`
+	MaxPages   int    `json:"maxPages" yaml:"maxPages"`
+}
+
+// FieldMappings maps paths inside each job object onto jobs.Job fields.
+type FieldMappings struct {
+	Title        FieldMapping `json:"title" yaml:"title"`
+	Company      FieldMapping `json:"company" yaml:"company"`
+	Description  FieldMapping `json:"description" yaml:"description"`
+	Salary       FieldMapping `json:"salary" yaml:"salary"`
+	Location     FieldMapping `json:"location" yaml:"location"`
+	URL          FieldMapping `json:"url" yaml:"url"`
+	SourceID     FieldMapping `json:"sourceId" yaml:"sourceId"`
+	PostedAt     FieldMapping `json:"postedAt" yaml:"postedAt"`
+	ValidThrough FieldMapping `json:"validThrough" yaml:"validThrough"`
+	Skills       TagMapping   `json:"skills" yaml:"skills"`
+}
+
+// FieldMapping reads a dotted path such as "company.name" or "locations.0".
+// It can be written as a plain path string.
+type FieldMapping struct {
+	Path    string `json:"path" yaml:"path"`
+	Default string `json:"default" yaml:"default"`
+	Prefix  string `json:"prefix" yaml:"prefix"`
+}
+
+// TagMapping reads either an array of strings or a single delimited string.
+type TagMapping struct {
+	Path      string `json:"path" yaml:"path"`
+	Separator string `json:"separator" yaml:"separator"`
+}
+
+func (f *FieldMapping) UnmarshalJSON(data []byte) error {
+	var path string
+	if err := json.Unmarshal(data, &path); err == nil {
+		f.Path = path
+		return nil
+	}
+	type plain FieldMapping
+	return json.Unmarshal(data, (*plain)(f))
+}
+
+func (f *FieldMapping) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		f.Path = value.Value
+		return nil
+	}
+	type plain FieldMapping
+	return value.Decode((*plain)(f))
+}
+
+func (t *TagMapping) UnmarshalJSON(data []byte) error {
+	var path string
+	if err := json.Unmarshal(data, &path); err == nil {
+		t.Path = path
+		return nil
+	}
+	type plain TagMapping
+	return json.Unmarshal(data, (*plain)(t))
+}
+
+func (t *TagMapping) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		t.Path = value.Value
+		return nil
+	}
+	type plain TagMapping
+	return value.Decode((*plain)(t))
+}
+
+func (d *Definition) validate() error {
+	switch {
+	case d.Name == "":
+		return errors.New("name is required")
+	case d.URL == "":
+		return errors.New("url is required")
+	case d.Fields.Title.Path == "":
+		return errors.New("fields.title is required")
+	case d.Fields.Company.Path == "" && d.Fields.Company.Default == "":
+		return errors.New("fields.company is required")
+	case d.Fields.SourceID.Path == "" && d.Fields.URL.Path == "":
+		return errors.New("fields.sourceId or fields.url is required")
+	}
+
+	switch d.Pagination.Type {
+	case "", "none":
+	case "page", "offset", "cursor":
+		if d.Pagination.Param == "" {
+			return fmt.Errorf("pagination.param is required for %q pagination", d.Pagination.Type)
+		}
+		if d.Pagination.Type == "cursor" && d.Pagination.CursorPath == "" {
+			return errors.New("pagination.cursorPath is required for cursor pagination")
+		}
+		if d.Pagination.Type == "offset" && d.Pagination.PageSize <= 0 {
+			return errors.New("pagination.pageSize is required for offset pagination")
+		}
+	case "next":
+		if d.Pagination.CursorPath == "" {
+			return errors.New("pagination.cursorPath is required for next pagination")
+		}
+	default:
+		return fmt.Errorf("unknown pagination type %q", d.Pagination.Type)
+	}
+	return nil
+}
+
+// LoadDefinitions parses every .yaml, .yml and .json file in dir. A missing
+// directory is not an error; an invalid file is reported and skipped.
+func LoadDefinitions(dir string) ([]*Definition, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		defs []*Definition
+		errs []error
+	)
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml" && ext != ".json") {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		def, err := loadDefinition(path, ext)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", path, err))
+			continue
+		}
+		defs = append(defs, def)
+	}
+
+	return defs, errors.Join(errs...)
+}
+
+func loadDefinition(path, ext string) (*Definition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var def Definition
+	if ext == ".json" {
+		err = json.Unmarshal(data, &def)
+	} else {
+		err = yaml.Unmarshal(data, &def)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := def.validate(); err != nil {
+		return nil, err
+	}
+	return &def, nil
+}
+
+// Definition Scraper runs a declarative Definition.
+type DefinitionScraper struct {
+	httpSource
+	def *Definition
+}
+
+func NewDefinitionScraper(def *Definition) *DefinitionScraper {
+	return &DefinitionScraper{
+		httpSource: newHTTPSource(""),
+		def:        def,
+	}
+}
+
+func (s *DefinitionScraper) Name() string { return s.def.Name }
+
+const defaultMaxPages = 10
+
+func (s *DefinitionScraper) Scrape(ctx context.Context) ([]jobs.Job, error) {
+	p := s.def.Pagination
+	maxPages := p.MaxPages
+	if maxPages <= 0 {
+		maxPages = defaultMaxPages
+	}
+	if p.Type == "" || p.Type == "none" {
+		maxPages = 1
+	}
+
+	var result []jobs.Job
+	nextURL := s.def.URL
+	cursor := ""
+
+	for page := 0; page < maxPages && nextURL != ""; page++ {
+		pageURL, err := s.pageURL(nextURL, page, cursor)
+		if err != nil {
+			return result, err
+		}
+
+		body, err := s.fetch(ctx, pageURL)
+		if err != nil {
+			// Keep the jobs from earlier pages
+			return result, fmt.Errorf("page %d: %w", page+1, err)
+		}
+
+		items, _ := lookupPath(body, s.def.JobsPath).([]interface{})
+		for _, item := range items {
+			if job, ok := s.mapJob(item); ok {
+				result = append(result, job)
+			}
+		}
+		if len(items) == 0 {
+			break
+		}
+
+		switch p.Type {
+		case "cursor":
+			cursor = lookupString(body, p.CursorPath)
+			if cursor == "" {
+				nextURL = ""
+			}
+		case "next":
+			nextURL = lookupString(body, p.CursorPath)
+		}
+	}
+
+	return result, nil
+}
+
+func (s *DefinitionScraper) pageURL(base string, page int, cursor string) (string, error) {
+	p := s.def.Pagination
+	if p.Type == "" || p.Type == "none" || p.Type == "next" {
+		return base, nil
+	}
+
+	u, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+	q := u.Query()
+	switch p.Type {
+	case "page":
+		q.Set(p.Param, strconv.Itoa(p.Start+page))
+	case "offset":
+		q.Set(p.Param, strconv.Itoa(p.Start+page*p.PageSize))
+	case "cursor":
+		if cursor != "" {
+			q.Set(p.Param, cursor)
+		}
+	}
+	if p.SizeParam != "" && p.PageSize > 0 {
+		q.Set(p.SizeParam, strconv.Itoa(p.PageSize))
+	}
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+func (s *DefinitionScraper) fetch(ctx context.Context, pageURL string) (interface{}, error) {
+	var body interface{}
+	if err := s.getJSON(ctx, pageURL, s.def.Headers, &body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+func (s *DefinitionScraper) mapJob(item interface{}) (jobs.Job, bool) {
+	f := s.def.Fields
+	job := jobs.Job{
+		Title:       f.Title.read(item),
+		Company:     f.Company.read(item),
+		Description: f.Description.read(item),
+		Salary:      f.Salary.read(item),
+		Location:    f.Location.read(item),
+		URL:         f.URL.read(item),
+		Source:      s.def.Name,
+		SourceID:    f.SourceID.read(item),
+		Skills:      f.Skills.read(item),
+		IsActive:    true,
+	}
+	if job.SourceID == "" {
+		job.SourceID = job.URL
+	}
+
+	if t, ok := parseLayouts(f.PostedAt.read(item), s.def.DateLayouts); ok {
+		job.PostedAt = t
+	}
+	if t, ok := parseLayouts(f.ValidThrough.read(item), s.def.DateLayouts); ok {
+		job.ValidThrough = &t
+	}
+
+	if job.Title == "" || job.Company == "" || job.SourceID == "" {
+		return jobs.Job{}, false
+	}
+	return job, true
+}
+
+func (f FieldMapping) read(item interface{}) string {
+	if f.Path == "" {
+		return f.Default
+	}
+	v := strings.TrimSpace(lookupString(item, f.Path))
+	if v == "" {
+		return f.Default
+	}
+	return f.Prefix + v
+}
+
+func (t TagMapping) read(item interface{}) []string {
+	if t.Path == "" {
+		return []string{}
+	}
+	switch v := lookupPath(item, t.Path).(type) {
+	case []interface{}:
+		return parseTagsArray(v)
+	case string:
+		if t.Separator == "" || t.Separator == "," {
+			return parseSkills(v)
+		}
+		return parseSkills(strings.ReplaceAll(v, t.Separator, ","))
+	}
+	return []string{}
+}
+
+// parseLayouts tries each layout in turn. The pseudo-layouts "unix" and
+// "unixms" read epoch seconds and milliseconds. Without layouts, any
+// format parseDate knows is accepted.
+func parseLayouts(s string, layouts []string) (time.Time, bool) {
+	if s == "" {
+		return time.Time{}, false
+	}
+	if len(layouts) == 0 {
+		return parseDate(s)
+	}
+	for _, layout := range layouts {
+		switch layout {
+		case "unix", "unixms":
+			n, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				continue
+			}
+			if layout == "unixms" {
+				return time.UnixMilli(int64(n)).UTC(), true
+			}
+			return time.Unix(int64(n), 0).UTC(), true
+		default:
+			if t, err := time.Parse(layout, s); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// lookupPath follows a dotted path through decoded JSON. Numeric segments
+// index into arrays; an empty path returns v itself.
+func lookupPath(v interface{}, path string) interface{} {
+	if path == "" {
+		return v
+	}
+	for _, key := range strings.Split(path, ".") {
+		switch node := v.(type) {
+		case map[string]interface{}:
+			v = node[key]
+		case []interface{}:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil
+			}
+			v = node[i]
+		default:
+			return nil
+		}
+	}
+	return v
+}
+
+// lookupString is lookupPath for scalar values, formatting numbers and
+// booleans so IDs and epoch timestamps can be mapped like strings.
+func lookupString(v interface{}, path string) string {
+	switch val := lookupPath(v, path).(type) {
+	case string:
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(val)
+	}
+	return ""
+}
+
+// loadDefinitionScrapers builds scrapers for every definition in dir,
+// skipping any whose name clashes with an existing scraper.
+func loadDefinitionScrapers(dir string, existing []Scraper) []Scraper {
+	defs, err := LoadDefinitions(dir)
+	if err != nil {
+		log.Printf("⚠️ Loading scraper definitions: %v", err)
+	}
+
+	names := make(map[string]bool, len(existing))
+	for _, s := range existing {
+		names[s.Name()] = true
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Name < defs[j].Name })
+
+	var result []Scraper
+	for _, def := range defs {
+		if names[def.Name] {
+			log.Printf("⚠️ Skipping scraper definition %q: name already registered", def.Name)
+			continue
+		}
+		names[def.Name] = true
+		result = append(result, NewDefinitionScraper(def))
+	}
+	return result
+}
@@ -0,0 +1,112 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLoadDefinitionsShipped(t *testing.T) {
+	defs, err := LoadDefinitions("../../scrapers")
+	if err != nil {
+		t.Fatalf("LoadDefinitions: %v", err)
+	}
+	if len(defs) == 0 {
+		t.Fatal("no shipped definitions found")
+	}
+}
+
+func TestLoadDefinitionsInvalid(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "ok.yaml"), []byte("name: Ok\nurl: http://x\nfields: {title: t, company: c, sourceId: id}\n"), 0o644)
+	os.WriteFile(filepath.Join(dir, "bad.json"), []byte(`{"name": "Bad", "url": "http://x"}`), 0o644)
+	os.WriteFile(filepath.Join(dir, "README.md"), []byte("ignored"), 0o644)
+
+	defs, err := LoadDefinitions(dir)
+	if err == nil {
+		t.Error("expected an error for the invalid definition")
+	}
+	if len(defs) != 1 || defs[0].Name != "Ok" {
+		t.Errorf("got %+v, want only the valid definition", defs)
+	}
+
+	if defs, err := LoadDefinitions(filepath.Join(dir, "missing")); err != nil || defs != nil {
+		t.Errorf("missing dir: got %v, %v", defs, err)
+	}
+}
+
+func TestDefinitionScraperPagination(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		if r.URL.Query().Get("per_page") != "2" {
+			t.Errorf("missing page size: %s", r.URL)
+		}
+		switch page {
+		case "1":
+			fmt.Fprint(w, `{"result": {"items": [
+				{"id": 101, "role": "Go Developer", "org": {"name": "Acme"}, "tags": "go|postgres", "published": 1728900000},
+				{"id": 102, "role": "", "org": {"name": "Acme"}}
+			]}}`)
+		case "2":
+			fmt.Fprint(w, `{"result": {"items": [
+				{"id": 103, "role": "Designer", "org": {"name": "Globex"}, "where": ["Berlin", "Remote"]}
+			]}}`)
+		default:
+			fmt.Fprint(w, `{"result": {"items": []}}`)
+		}
+	}))
+	defer srv.Close()
+
+	def := &Definition{
+		Name:        "Example",
+		URL:         srv.URL + "/jobs?lang=en",
+		JobsPath:    "result.items",
+		Pagination:  Pagination{Type: "page", Param: "page", Start: 1, PageSize: 2, SizeParam: "per_page"},
+		DateLayouts: []string{"unix"},
+		Fields: FieldMappings{
+			Title:    FieldMapping{Path: "role"},
+			Company:  FieldMapping{Path: "org.name"},
+			Location: FieldMapping{Path: "where.0", Default: "Remote"},
+			URL:      FieldMapping{Path: "id", Prefix: "https://example.com/jobs/"},
+			SourceID: FieldMapping{Path: "id"},
+			PostedAt: FieldMapping{Path: "published"},
+			Skills:   TagMapping{Path: "tags", Separator: "|"},
+		},
+	}
+	if err := def.validate(); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+
+	s := NewDefinitionScraper(def)
+	s.client = srv.Client()
+	got, err := s.Scrape(context.Background())
+	if err != nil {
+		t.Fatalf("Scrape: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d jobs, want 2", len(got))
+	}
+
+	first := got[0]
+	if first.Source != "Example" || first.SourceID != "101" || first.URL != "https://example.com/jobs/101" {
+		t.Errorf("source/sourceId/url = %q/%q/%q", first.Source, first.SourceID, first.URL)
+	}
+	if first.Company != "Acme" || first.Location != "Remote" {
+		t.Errorf("company/location = %q/%q", first.Company, first.Location)
+	}
+	if want := []string{"go", "postgres"}; !reflect.DeepEqual(first.Skills, want) {
+		t.Errorf("skills = %v, want %v", first.Skills, want)
+	}
+	if !first.PostedAt.Equal(time.Unix(1728900000, 0)) {
+		t.Errorf("postedAt = %v", first.PostedAt)
+	}
+	if got[1].Location != "Berlin" {
+		t.Errorf("location = %q", got[1].Location)
+	}
+}
@@ -0,0 +1,265 @@
+package scraper
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// maxResponseSize caps how much of a response body is read.
+const maxResponseSize = 32 << 20
+
+var errResponseTooLarge = errors.New("response too large")
+
+// StatusError is returned when a source answers with a status other than
+// 2xx, after any retries.
+type StatusError struct {
+	URL        string
+	StatusCode int
+	Status     string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("GET %s: unexpected status %s", e.URL, e.Status)
+}
+
+// retryPolicy controls how failed requests are retried. Delays grow
+// exponentially from baseDelay up to maxDelay, with full jitter, unless the
+// source sends a Retry-After header.
+type retryPolicy struct {
+	retries       int
+	baseDelay     time.Duration
+	maxDelay      time.Duration
+	maxRetryAfter time.Duration
+}
+
+// defaultRetry is the policy scrapers start with. The scraper manager
+// gives its scrapers a copy with the retry count from the config.
+var defaultRetry = retryPolicy{
+	retries:       3,
+	baseDelay:     time.Second,
+	maxDelay:      30 * time.Second,
+	maxRetryAfter: 2 * time.Minute,
+}
+
+// backoff returns the delay before retry number attempt, counting from 0.
+func (p *retryPolicy) backoff(attempt int) time.Duration {
+	d := p.baseDelay << attempt
+	if d <= 0 || d > p.maxDelay {
+		d = p.maxDelay
+	}
+	if d <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(d) + 1))
+}
+
+// retryable reports whether a status is worth retrying.
+func retryable(status int) bool {
+	switch status {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests,
+		http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryAfter reads a Retry-After header given in seconds or as an HTTP date.
+func retryAfter(h http.Header, now time.Time) (time.Duration, bool) {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(t.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// hostLimiter spaces out requests to the same host. The scraper manager
+// shares one between all its scrapers.
+type hostLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     map[string]time.Time
+}
+
+func newHostLimiter(interval time.Duration) *hostLimiter {
+	return &hostLimiter{interval: interval, next: make(map[string]time.Time)}
+}
+
+// wait blocks until a request to host is allowed and reserves the slot.
+func (l *hostLimiter) wait(ctx context.Context, host string) error {
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next[host]
+	if at.Before(now) {
+		at = now
+	}
+	l.next[host] = at.Add(l.interval)
+	l.mu.Unlock()
+
+	return sleep(ctx, at.Sub(now))
+}
+
+// delay holds back further requests to host for d, as a source asked
+// with Retry-After.
+func (l *hostLimiter) delay(host string, d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if until := time.Now().Add(d); until.After(l.next[host]) {
+		l.next[host] = until
+	}
+}
+
+// cachedResponse is the last body a URL returned, with the validators to
+// ask whether it changed.
+type cachedResponse struct {
+	URL          string    `bson:"_id"`
+	ETag         string    `bson:"etag,omitempty"`
+	LastModified string    `bson:"lastModified,omitempty"`
+	Body         []byte    `bson:"body"`
+	StoredAt     time.Time `bson:"storedAt"`
+}
+
+const (
+	// responseCacheBytes bounds the bodies a responseCache keeps in memory.
+	responseCacheBytes = 64 << 20
+	// maxStoredResponse is the largest body saved to the responseStore,
+	// well below MongoDB's document size limit.
+	maxStoredResponse = 8 << 20
+)
+
+// responseStore persists cached responses, so validators survive restarts
+// and are shared between instances.
+type responseStore interface {
+	Find(ctx context.Context, url string) (*cachedResponse, error)
+	Save(ctx context.Context, r *cachedResponse) error
+}
+
+// responseCache keeps the most recently used cachedResponses in memory, up
+// to maxBytes of bodies, backed by store when it is set. A conditional GET
+// that comes back 304 Not Modified reuses the cached body.
+type responseCache struct {
+	store    responseStore
+	maxBytes int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+	size    int
+}
+
+func newResponseCache(store responseStore, maxBytes int) *responseCache {
+	return &responseCache{
+		store:    store,
+		maxBytes: maxBytes,
+		entries:  make(map[string]*list.Element),
+		lru:      list.New(),
+	}
+}
+
+// get returns the cached response for url, reading it from the store when
+// it is not held in memory.
+func (c *responseCache) get(ctx context.Context, url string) (cachedResponse, bool) {
+	c.mu.Lock()
+	if el, ok := c.entries[url]; ok {
+		c.lru.MoveToFront(el)
+		entry := *el.Value.(*cachedResponse)
+		c.mu.Unlock()
+		return entry, true
+	}
+	c.mu.Unlock()
+
+	if c.store == nil {
+		return cachedResponse{}, false
+	}
+	stored, err := c.store.Find(ctx, url)
+	if err != nil {
+		log.Printf("⚠️ Failed to load cached response for %s: %v", url, err)
+		return cachedResponse{}, false
+	}
+	if stored == nil {
+		return cachedResponse{}, false
+	}
+	c.remember(stored)
+	return *stored, true
+}
+
+// put remembers body if the response had validators to revalidate it.
+func (c *responseCache) put(ctx context.Context, url string, h http.Header, body []byte) {
+	entry := &cachedResponse{
+		URL:          url,
+		ETag:         h.Get("ETag"),
+		LastModified: h.Get("Last-Modified"),
+		Body:         body,
+		StoredAt:     time.Now(),
+	}
+	if entry.ETag == "" && entry.LastModified == "" {
+		c.forget(url)
+		return
+	}
+	c.remember(entry)
+
+	if c.store != nil && len(body) <= maxStoredResponse {
+		if err := c.store.Save(ctx, entry); err != nil {
+			log.Printf("⚠️ Failed to save cached response for %s: %v", url, err)
+		}
+	}
+}
+
+// remember adds entry to memory, evicting the least recently used entries
+// beyond maxBytes.
+func (c *responseCache) remember(entry *cachedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.removeLocked(entry.URL)
+	if len(entry.Body) > c.maxBytes {
+		return
+	}
+	c.entries[entry.URL] = c.lru.PushFront(entry)
+	c.size += len(entry.Body)
+	for c.size > c.maxBytes {
+		c.removeLocked(c.lru.Back().Value.(*cachedResponse).URL)
+	}
+}
+
+func (c *responseCache) forget(url string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.removeLocked(url)
+}
+
+func (c *responseCache) removeLocked(url string) {
+	if el, ok := c.entries[url]; ok {
+		c.size -= len(el.Value.(*cachedResponse).Body)
+		c.lru.Remove(el)
+		delete(c.entries, url)
+	}
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
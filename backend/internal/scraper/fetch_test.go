@@ -0,0 +1,193 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestSource(url string) httpSource {
+	h := newHTTPSource(url)
+	h.retry = &retryPolicy{retries: 2, baseDelay: time.Millisecond, maxDelay: 5 * time.Millisecond, maxRetryAfter: time.Second}
+	h.limiter = newHostLimiter(0)
+	return h
+}
+
+func TestGetRetries(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/down" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		switch calls.Add(1) {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.Write([]byte(`{"ok":true}`))
+		}
+	}))
+	defer srv.Close()
+
+	h := newTestSource(srv.URL)
+	var got struct{ OK bool }
+	if err := h.getJSON(context.Background(), "/api", nil, &got); err != nil || !got.OK {
+		t.Fatalf("getJSON = %v, %+v", err, got)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Errorf("made %d requests, want 3", n)
+	}
+
+	// Retries run out
+	var statusErr *StatusError
+	if _, err := h.get(context.Background(), "/down", nil); !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("get error = %v, want a 503 StatusError", err)
+	}
+}
+
+func TestGetDoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.URL.Path == "/html" {
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte("<!DOCTYPE html><title>Down for maintenance</title>"))
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	h := newTestSource(srv.URL)
+	if _, err := h.get(context.Background(), "/missing", nil); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("get error = %v, want a 404", err)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("made %d requests, want 1", n)
+	}
+
+	var v interface{}
+	if err := h.getJSON(context.Background(), "/html", nil, &v); err == nil || !strings.Contains(err.Error(), "<!DOCTYPE html>") {
+		t.Errorf("getJSON error = %v, want the start of the HTML page", err)
+	}
+}
+
+func TestGetConditional(t *testing.T) {
+	var full atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		full.Add(1)
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte("feed"))
+	}))
+	defer srv.Close()
+
+	h := newTestSource(srv.URL)
+	for i := 0; i < 2; i++ {
+		body, err := h.get(context.Background(), "/feed", nil)
+		if err != nil || string(body) != "feed" {
+			t.Fatalf("get #%d = %q, %v", i+1, body, err)
+		}
+	}
+	if n := full.Load(); n != 1 {
+		t.Errorf("served the full feed %d times, want 1", n)
+	}
+}
+
+// memoryResponseStore is a responseStore kept in a map.
+type memoryResponseStore struct {
+	mu        sync.Mutex
+	responses map[string]cachedResponse
+}
+
+func (s *memoryResponseStore) Find(ctx context.Context, url string) (*cachedResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r, ok := s.responses[url]; ok {
+		return &r, nil
+	}
+	return nil, nil
+}
+
+func (s *memoryResponseStore) Save(ctx context.Context, r *cachedResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.responses[r.URL] = *r
+	return nil
+}
+
+func TestGetConditionalAfterRestart(t *testing.T) {
+	var full atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		full.Add(1)
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte("feed"))
+	}))
+	defer srv.Close()
+
+	store := &memoryResponseStore{responses: map[string]cachedResponse{}}
+	for i := 0; i < 2; i++ {
+		// Each source starts with an empty memory, as after a cold start
+		h := newTestSource(srv.URL)
+		h.cache = newResponseCache(store, responseCacheBytes)
+		body, err := h.get(context.Background(), "/feed", nil)
+		if err != nil || string(body) != "feed" {
+			t.Fatalf("get #%d = %q, %v", i+1, body, err)
+		}
+	}
+	if n := full.Load(); n != 1 {
+		t.Errorf("served the full feed %d times, want 1", n)
+	}
+}
+
+func TestResponseCacheEvicts(t *testing.T) {
+	ctx := context.Background()
+	c := newResponseCache(nil, 10)
+	header := http.Header{"Etag": {`"v1"`}}
+	c.put(ctx, "a", header, []byte("aaaa"))
+	c.put(ctx, "b", header, []byte("bbbb"))
+	c.get(ctx, "a")
+	c.put(ctx, "c", header, []byte("cccc"))
+	c.put(ctx, "huge", header, []byte("more than ten bytes"))
+
+	for url, want := range map[string]bool{"a": true, "b": false, "c": true, "huge": false} {
+		if _, ok := c.get(ctx, url); ok != want {
+			t.Errorf("cached %q = %v, want %v", url, ok, want)
+		}
+	}
+	if c.size > 10 {
+		t.Errorf("cache holds %d bytes, want at most 10", c.size)
+	}
+}
+
+func TestHostLimiter(t *testing.T) {
+	l := newHostLimiter(20 * time.Millisecond)
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := l.wait(context.Background(), "example.com"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("three requests took %s, want at least 40ms", elapsed)
+	}
+	if err := l.wait(context.Background(), "other.example"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,64 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+)
+
+// httpScraper is a Scraper whose requests can be redirected.
+type httpScraper interface {
+	Scraper
+	SetBaseURL(baseURL string)
+	SetTransport(rt http.RoundTripper)
+}
+
+// TestScrapersGolden replays each source's recorded responses and compares
+// the jobs produced with the golden files, so a change in how a scraper
+// reads its source shows up as a diff. Run with -record to refresh the
+// recordings from the live sources, or -update to accept new output.
+func TestScrapersGolden(t *testing.T) {
+	tests := []struct {
+		name    string
+		scraper httpScraper
+	}{
+		{"remoteok", NewRemoteOKScraper()},
+		{"remotive", NewRemotiveScraper()},
+		{"weworkremotely", NewWeWorkRemotelyScraper()},
+		{"lever", NewLeverScraper([]string{"leverdemo=Lever Demo"})},
+		{"greenhouse", NewGreenhouseScraper([]string{"gitlab"})},
+		{"hackernews", NewHackerNewsScraper(nil)},
+		{"arbeitnow", definitionScraper(t, "Arbeitnow")},
+		{"jobicy", definitionScraper(t, "Jobicy")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.scraper.SetTransport(newCassette(t, tt.name))
+			got, err := tt.scraper.Scrape(context.Background())
+			if err != nil {
+				t.Fatalf("Scrape: %v", err)
+			}
+			if len(got) == 0 {
+				t.Fatal("no jobs scraped")
+			}
+			assertGolden(t, tt.name, got)
+		})
+	}
+}
+
+// definitionScraper loads the shipped definition with the given name.
+func definitionScraper(t *testing.T, name string) *DefinitionScraper {
+	t.Helper()
+	defs, err := LoadDefinitions(filepath.Join("..", "..", "scrapers"))
+	if err != nil {
+		t.Fatalf("loading definitions: %v", err)
+	}
+	for _, def := range defs {
+		if def.Name == name {
+			return NewDefinitionScraper(def)
+		}
+	}
+	t.Fatalf("no definition named %q", name)
+	return nil
+}
@@ -0,0 +1,119 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+
+	"github.com/hiresense/backend/internal/jobs"
+	"github.com/hiresense/backend/internal/sources"
+)
+
+// Greenhouse Scraper reads the public job board API of each configured board token.
+type GreenhouseScraper struct {
+	httpSource
+	boards []string
+}
+
+func NewGreenhouseScraper(boards []string) *GreenhouseScraper {
+	return &GreenhouseScraper{
+		httpSource: newHTTPSource("https://boards-api.greenhouse.io/v1/boards"),
+		boards:     boards,
+	}
+}
+
+func (s *GreenhouseScraper) Name() string { return "Greenhouse" }
+
+func (s *GreenhouseScraper) Settings() sources.Settings {
+	return sources.Settings{"boards": s.boards}
+}
+
+func (s *GreenhouseScraper) Configure(settings sources.Settings) { s.boards = settings["boards"] }
+
+type greenhouseJob struct {
+	ID          int64  `json:"id"`
+	Title       string `json:"title"`
+	AbsoluteURL string `json:"absolute_url"`
+	// FirstPublished is when the posting went up; updated_at moves with
+	// every edit, so it is not used as the posting date
+	FirstPublished string `json:"first_published"`
+	CompanyName    string `json:"company_name"`
+	Content        string `json:"content"`
+	Location       struct {
+		Name string `json:"name"`
+	} `json:"location"`
+	Departments []struct {
+		Name string `json:"name"`
+	} `json:"departments"`
+}
+
+func (s *GreenhouseScraper) Scrape(ctx context.Context) ([]jobs.Job, error) {
+	var (
+		result []jobs.Job
+		errs   []error
+	)
+
+	if len(s.boards) == 0 {
+		return nil, errors.New("no boards configured")
+	}
+	for _, board := range s.boards {
+		boardJobs, err := s.fetchBoard(ctx, board)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", board, err))
+			continue
+		}
+		for _, gj := range boardJobs {
+			if job, ok := greenhouseToJob(board, gj); ok {
+				result = append(result, job)
+			}
+		}
+	}
+
+	return result, errors.Join(errs...)
+}
+
+func (s *GreenhouseScraper) fetchBoard(ctx context.Context, board string) ([]greenhouseJob, error) {
+	var response struct {
+		Jobs []greenhouseJob `json:"jobs"`
+	}
+	if err := s.getJSON(ctx, "/"+url.PathEscape(board)+"/jobs?content=true", nil, &response); err != nil {
+		return nil, err
+	}
+	return response.Jobs, nil
+}
+
+func greenhouseToJob(board string, gj greenhouseJob) (jobs.Job, bool) {
+	if gj.ID == 0 || gj.Title == "" {
+		return jobs.Job{}, false
+	}
+
+	company := gj.CompanyName
+	if company == "" {
+		company = board
+	}
+
+	location := gj.Location.Name
+	if location == "" {
+		location = "Remote"
+	}
+
+	job := jobs.Job{
+		Title:       gj.Title,
+		Company:     company,
+		Description: html.UnescapeString(gj.Content), // content is HTML-escaped
+		Location:    location,
+		URL:         gj.AbsoluteURL,
+		Source:      "Greenhouse",
+		SourceID:    fmt.Sprintf("%s/%d", board, gj.ID),
+		Skills:      []string{},
+		IsActive:    true,
+	}
+
+	if t, ok := parsePostedDate(gj.FirstPublished); ok {
+		job.PostedAt = t
+	}
+
+	return job, true
+}
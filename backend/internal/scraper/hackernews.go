@@ -0,0 +1,239 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hiresense/backend/internal/jobs"
+	"github.com/hiresense/backend/internal/sources"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// HackerNews Scraper turns the top-level comments of the monthly
+// "Ask HN: Who is hiring?" threads into jobs, read through the Algolia HN API.
+type HackerNewsScraper struct {
+	httpSource
+	threadIDs []string
+}
+
+// NewHackerNewsScraper scrapes the given thread IDs, or the latest
+// "Who is hiring?" thread when none are configured.
+func NewHackerNewsScraper(threadIDs []string) *HackerNewsScraper {
+	return &HackerNewsScraper{
+		httpSource: newHTTPSource("https://hn.algolia.com/api/v1"),
+		threadIDs:  threadIDs,
+	}
+}
+
+func (s *HackerNewsScraper) Name() string { return "HackerNews" }
+
+func (s *HackerNewsScraper) Settings() sources.Settings {
+	return sources.Settings{"threadIds": s.threadIDs}
+}
+
+func (s *HackerNewsScraper) Configure(settings sources.Settings) { s.threadIDs = settings["threadIds"] }
+
+type hnItem struct {
+	ID         int64     `json:"id"`
+	CreatedAtI int64     `json:"created_at_i"`
+	Type       string    `json:"type"`
+	Author     string    `json:"author"`
+	Title      string    `json:"title"`
+	Text       string    `json:"text"`
+	Children   []*hnItem `json:"children"`
+}
+
+func (s *HackerNewsScraper) Scrape(ctx context.Context) ([]jobs.Job, error) {
+	threadIDs := s.threadIDs
+	if len(threadIDs) == 0 {
+		latest, err := s.latestThreadID(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("finding latest thread: %w", err)
+		}
+		threadIDs = []string{latest}
+	}
+
+	var (
+		result []jobs.Job
+		errs   []error
+	)
+	for _, id := range threadIDs {
+		var thread hnItem
+		if err := s.getJSON(ctx, "/items/"+id, nil, &thread); err != nil {
+			errs = append(errs, fmt.Errorf("thread %s: %w", id, err))
+			continue
+		}
+		result = append(result, parseHNThread(&thread)...)
+	}
+
+	return result, errors.Join(errs...)
+}
+
+// latestThreadID finds the most recent thread posted by the whoishiring account.
+func (s *HackerNewsScraper) latestThreadID(ctx context.Context) (string, error) {
+	var search struct {
+		Hits []struct {
+			ObjectID string `json:"objectID"`
+			Title    string `json:"title"`
+		} `json:"hits"`
+	}
+	if err := s.getJSON(ctx, "/search_by_date?tags=story,author_whoishiring&hitsPerPage=10", nil, &search); err != nil {
+		return "", err
+	}
+	for _, hit := range search.Hits {
+		if strings.HasPrefix(strings.ToLower(hit.Title), "ask hn: who is hiring") {
+			return hit.ObjectID, nil
+		}
+	}
+	return "", errors.New("no hiring thread found")
+}
+
+func parseHNThread(thread *hnItem) []jobs.Job {
+	result := make([]jobs.Job, 0, len(thread.Children))
+	for _, comment := range thread.Children {
+		// Replies to job posts are nested, so only direct children are postings
+		if comment == nil || comment.Text == "" || comment.Author == "" {
+			continue
+		}
+		if job, ok := hnCommentToJob(comment); ok {
+			result = append(result, job)
+		}
+	}
+	return result
+}
+
+func hnCommentToJob(comment *hnItem) (jobs.Job, bool) {
+	header, _, _ := strings.Cut(comment.Text, "<p>")
+	h := parseHNHeader(htmlText(header))
+	if h.company == "" || h.title == "" {
+		return jobs.Job{}, false
+	}
+
+	id := strconv.FormatInt(comment.ID, 10)
+	job := jobs.Job{
+		Title:       h.title,
+		Company:     h.company,
+		Description: comment.Text,
+		Salary:      h.salary,
+		Location:    h.location,
+		URL:         "https://news.ycombinator.com/item?id=" + id,
+		Source:      "HackerNews",
+		SourceID:    id,
+		Skills:      []string{},
+		IsActive:    true,
+	}
+	if comment.CreatedAtI > 0 {
+		job.PostedAt = time.Unix(comment.CreatedAtI, 0).UTC()
+	}
+	return job, true
+}
+
+type hnHeader struct {
+	company  string
+	title    string
+	location string
+	salary   string
+}
+
+var (
+	hnLocationPattern = regexp.MustCompile(`(?i)\b(remote|onsite|on-site|on site|hybrid|in-office|in office)\b`)
+	hnSalaryPattern   = regexp.MustCompile(`(?i)([$€£]\s?\d|\d+\s?k\b|\bsalary\b|\bequity\b|\b(usd|eur|gbp)\b)`)
+	hnRolePattern     = regexp.MustCompile(`(?i)\b(engineers?|developers?|designers?|managers?|scientists?|architects?|analysts?|researchers?|sre|devops|lead|head of|director|cto|vp|founding|intern(ship)?|product|recruiter|programmer|administrator|specialist|consultant)\b`)
+	hnURLPattern      = regexp.MustCompile(`(?i)^(https?://|www\.)\S+$`)
+	hnIgnorePattern   = regexp.MustCompile(`(?i)\b(full[- ]?time|part[- ]?time|contract|visa|h1b|relocation)\b`)
+)
+
+// parseHNHeader heuristically classifies the segments of a header line
+// such as "Acme | Senior Engineer | REMOTE (US) | $150k-$180k".
+func parseHNHeader(line string) hnHeader {
+	parts := strings.Split(line, "|")
+	if len(parts) < 2 {
+		return hnHeader{}
+	}
+
+	var h hnHeader
+	var roles, unknown []string
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		role, salary := splitHNRole(part)
+		switch {
+		case part == "":
+		case i == 0:
+			h.company = part
+		case hnURLPattern.MatchString(part):
+		case role != "":
+			roles = append(roles, role)
+			if h.salary == "" {
+				h.salary = salary
+			}
+		case hnSalaryPattern.MatchString(part) && h.salary == "":
+			h.salary = part
+		case hnLocationPattern.MatchString(part) && h.location == "":
+			h.location = part
+		case hnIgnorePattern.MatchString(part):
+		default:
+			unknown = append(unknown, part)
+		}
+	}
+
+	// Posters don't follow a fixed order, so unclassified segments fill in
+	// the role first and then the location.
+	if len(roles) == 0 && len(unknown) > 0 {
+		roles, unknown = unknown[:1], unknown[1:]
+	}
+	if h.location == "" && len(unknown) > 0 {
+		h.location = unknown[0]
+	}
+	h.title = strings.Join(roles, ", ")
+
+	return h
+}
+
+// splitHNRole reads a header segment naming a role, such as "Senior
+// Engineer ($180k)", into the role and any salary following it. It
+// returns no role when the segment doesn't start with one.
+func splitHNRole(part string) (role, salary string) {
+	loc := hnSalaryPattern.FindStringIndex(part)
+	if loc == nil {
+		if hnRolePattern.MatchString(part) {
+			return part, ""
+		}
+		return "", ""
+	}
+	role = strings.TrimRight(part[:loc[0]], " (-–,:")
+	if !hnRolePattern.MatchString(role) {
+		return "", ""
+	}
+	return role, strings.Trim(part[loc[0]:], " ()")
+}
+
+// htmlText returns the text content of an HTML fragment.
+func htmlText(fragment string) string {
+	nodes, err := html.ParseFragment(strings.NewReader(fragment), &html.Node{
+		Type: html.ElementNode, Data: "body", DataAtom: atom.Body,
+	})
+	if err != nil {
+		return fragment
+	}
+
+	var b strings.Builder
+	var visit func(n *html.Node)
+	visit = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			b.WriteString(n.Data)
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			visit(c)
+		}
+	}
+	for _, n := range nodes {
+		visit(n)
+	}
+	return strings.TrimSpace(b.String())
+}
@@ -0,0 +1,100 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHackerNewsScraper(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/search_by_date", func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, "testdata/hn_search.json")
+	})
+	mux.HandleFunc("/items/41709301", func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, "testdata/hn_thread.json")
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	s := NewHackerNewsScraper(nil)
+	s.client = srv.Client()
+	s.baseURL = srv.URL
+
+	got, err := s.Scrape(context.Background())
+	if err != nil {
+		t.Fatalf("Scrape: %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("got %d jobs, want 3: %+v", len(got), got)
+	}
+
+	acme := got[0]
+	if acme.Source != "HackerNews" || acme.SourceID != "41709410" {
+		t.Errorf("source/sourceId = %q/%q", acme.Source, acme.SourceID)
+	}
+	if acme.Company != "Acme Robotics" || acme.Title != "Senior Backend Engineer, Staff SRE" {
+		t.Errorf("company/title = %q/%q", acme.Company, acme.Title)
+	}
+	if acme.Location != "REMOTE (US, Canada)" || acme.Salary != "$170k-$210k + equity" {
+		t.Errorf("location/salary = %q/%q", acme.Location, acme.Salary)
+	}
+	if acme.URL != "https://news.ycombinator.com/item?id=41709410" {
+		t.Errorf("url = %q", acme.URL)
+	}
+	if !strings.Contains(acme.Description, "warehouse robots") {
+		t.Errorf("description = %q", acme.Description)
+	}
+	if !acme.PostedAt.Equal(time.Unix(1727794931, 0)) {
+		t.Errorf("postedAt = %v", acme.PostedAt)
+	}
+
+	globex := got[1]
+	if globex.Title != "Frontend Developer (React)" || globex.Location != "ONSITE" {
+		t.Errorf("title/location = %q/%q", globex.Title, globex.Location)
+	}
+
+	initech := got[2]
+	if initech.Title != "Data Platform" || initech.Location != "Hybrid (NYC)" {
+		t.Errorf("title/location = %q/%q", initech.Title, initech.Location)
+	}
+}
+
+func TestParseHNHeader(t *testing.T) {
+	tests := []struct {
+		line string
+		want hnHeader
+	}{
+		{
+			line: "Foo Inc | Remote | Product Designer",
+			want: hnHeader{company: "Foo Inc", title: "Product Designer", location: "Remote"},
+		},
+		{
+			line: "Bar | Machine Learning Engineer | London or REMOTE (UK) | £90k",
+			want: hnHeader{company: "Bar", title: "Machine Learning Engineer", location: "London or REMOTE (UK)", salary: "£90k"},
+		},
+		{
+			// A role with its salary in one segment is still a role
+			line: "Baz | Senior Engineer ($180k) | ONSITE",
+			want: hnHeader{company: "Baz", title: "Senior Engineer", location: "ONSITE", salary: "$180k"},
+		},
+		{
+			// No location is given, so none is assumed
+			line: "Qux | Backend Engineer | $150k-$170k",
+			want: hnHeader{company: "Qux", title: "Backend Engineer", salary: "$150k-$170k"},
+		},
+		{
+			line: "No pipes in this comment at all",
+			want: hnHeader{},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := parseHNHeader(tt.line); got != tt.want {
+			t.Errorf("parseHNHeader(%q) = %+v, want %+v", tt.line, got, tt.want)
+		}
+	}
+}
@@ -1,41 +1,507 @@
 package scraper
 
 import (
+	"context"
+	"errors"
+	"log"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/hiresense/backend/internal/config"
+	"github.com/hiresense/backend/internal/jobs"
+	"github.com/hiresense/backend/internal/sources"
+	"github.com/hiresense/backend/internal/users"
 )
 
 type Handler struct {
-	manager *ScraperManager
+	manager   *ScraperManager
+	scheduler *Scheduler
+	runRepo   *RunRepository
+	jobsRepo  *jobs.Repository
+	userRepo  *users.Repository
 }
 
 func NewHandler() *Handler {
+	manager := NewScraperManager()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := manager.SeedSources(ctx); err != nil {
+		log.Printf("⚠️ Failed to seed source registry: %v", err)
+	}
+
 	return &Handler{
-		manager: NewScraperManager(),
+		manager:   manager,
+		scheduler: NewScheduler(manager, config.AppConfig.ScrapeCron, config.AppConfig.ScrapeJitter),
+		runRepo:   NewRunRepository(),
+		jobsRepo:  jobs.NewRepository(),
+		userRepo:  users.NewRepository(),
 	}
 }
 
+// RunScheduler runs the built-in scrape scheduler until ctx is cancelled.
+func (h *Handler) RunScheduler(ctx context.Context) {
+	h.scheduler.Run(ctx)
+}
+
 func (h *Handler) RegisterRoutes(r *gin.RouterGroup) {
 	r.POST("/scrape", h.TriggerScrape)
+	r.GET("/scrape/:runId", h.GetScrapeRun)
+	r.DELETE("/scrape/:runId", h.CancelScrapeRun)
+	r.GET("/schedule", h.GetSchedules)
+	r.PUT("/schedule/:source", h.UpdateSchedule)
 	r.GET("/stats", h.GetStats)
+	r.GET("/sources", h.GetSources)
+	r.POST("/sources", h.CreateSource)
+	r.GET("/sources/health", h.GetSourceHealth)
+	r.GET("/sources/:id", h.GetSource)
+	r.PUT("/sources/:id", h.UpdateSource)
+	r.DELETE("/sources/:id", h.DeleteSource)
+	r.POST("/jobs/import", h.ImportJobs)
+	r.GET("/jobs/export", h.ExportJobs)
 }
 
 func (h *Handler) TriggerScrape(c *gin.Context) {
-	results := h.manager.RunAll(c.Request.Context())
-	c.JSON(http.StatusOK, gin.H{
-		"message": "Scrape completed",
-		"results": results,
+	runID, err := h.manager.Start(TriggerManual)
+	if err != nil {
+		if errors.Is(err, ErrRunInProgress) {
+			c.JSON(http.StatusConflict, gin.H{"error": "A scrape is already running", "runId": runID})
+			return
+		}
+		if errors.Is(err, ErrNoEnabledSources) {
+			c.JSON(http.StatusConflict, gin.H{"error": "No sources are enabled"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start scrape"})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "Scrape started",
+		"runId":   runID,
+	})
+}
+
+func (h *Handler) GetScrapeRun(c *gin.Context) {
+	run, err := h.manager.Run(c.Request.Context(), c.Param("runId"))
+	if err != nil {
+		if errors.Is(err, ErrRunNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Scrape run not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch scrape run"})
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
+
+func (h *Handler) CancelScrapeRun(c *gin.Context) {
+	err := h.manager.Cancel(c.Param("runId"))
+	switch {
+	case errors.Is(err, ErrRunNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Scrape run not found"})
+	case errors.Is(err, ErrRunFinished):
+		c.JSON(http.StatusConflict, gin.H{"error": "Scrape run already finished"})
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to cancel scrape"})
+	default:
+		c.JSON(http.StatusAccepted, gin.H{"message": "Scrape cancelling"})
+	}
+}
+
+func (h *Handler) GetSchedules(c *gin.Context) {
+	schedules, err := h.scheduler.Schedules(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch schedules"})
+		return
+	}
+
+	c.JSON(http.StatusOK, schedules)
+}
+
+type UpdateScheduleRequest struct {
+	Cron          string `json:"cron" binding:"required"`
+	JitterSeconds int    `json:"jitterSeconds"`
+	Enabled       bool   `json:"enabled"`
+}
+
+func (h *Handler) UpdateSchedule(c *gin.Context) {
+	var req UpdateScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	schedule, err := h.scheduler.UpdateSchedule(c.Request.Context(), Schedule{
+		Source:        c.Param("source"),
+		Cron:          req.Cron,
+		JitterSeconds: req.JitterSeconds,
+		Enabled:       req.Enabled,
 	})
+	switch {
+	case errors.Is(err, ErrScheduleNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Unknown source"})
+	case errors.Is(err, ErrInvalidSchedule):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update schedule"})
+	default:
+		c.JSON(http.StatusOK, schedule)
+	}
+}
+
+// SourceView is a registry entry with its scrape schedule.
+type SourceView struct {
+	sources.Source
+	Schedule *Schedule `json:"schedule,omitempty"`
+}
+
+// sourceViews joins registry entries with their schedules.
+func (h *Handler) sourceViews(ctx context.Context, srcs ...sources.Source) ([]SourceView, error) {
+	schedules, err := h.scheduler.Schedules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	views := make([]SourceView, len(srcs))
+	for i, src := range srcs {
+		views[i].Source = src
+		for j := range schedules {
+			if schedules[j].Source == src.ID {
+				views[i].Schedule = &schedules[j]
+			}
+		}
+	}
+	return views, nil
+}
+
+func (h *Handler) GetSources(c *gin.Context) {
+	ctx := c.Request.Context()
+	srcs, err := h.manager.ListSources(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch sources"})
+		return
+	}
+	views, err := h.sourceViews(ctx, srcs...)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch schedules"})
+		return
+	}
+
+	c.JSON(http.StatusOK, views)
+}
+
+func (h *Handler) GetSource(c *gin.Context) {
+	ctx := c.Request.Context()
+	src, err := h.manager.GetSource(ctx, c.Param("id"))
+	if errors.Is(err, sources.ErrSourceNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Source not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch source"})
+		return
+	}
+	h.respondSource(c, http.StatusOK, src)
+}
+
+// respondSource writes a source with its schedule.
+func (h *Handler) respondSource(c *gin.Context, status int, src *sources.Source) {
+	views, err := h.sourceViews(c.Request.Context(), *src)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch schedules"})
+		return
+	}
+	c.JSON(status, views[0])
+}
+
+type SourceRequest struct {
+	ID          string                 `json:"id"`
+	DisplayName string                 `json:"displayName"`
+	LogoURL     string                 `json:"logoUrl"`
+	Enabled     bool                   `json:"enabled"`
+	Settings    sources.Settings       `json:"settings"`
+	Schedule    *UpdateScheduleRequest `json:"schedule"`
+}
+
+// schedule returns the requested schedule change for source, if any.
+func (req *SourceRequest) schedule(source string) (*Schedule, error) {
+	if req.Schedule == nil {
+		return nil, nil
+	}
+	sched := &Schedule{
+		Source:        source,
+		Cron:          req.Schedule.Cron,
+		JitterSeconds: req.Schedule.JitterSeconds,
+		Enabled:       req.Schedule.Enabled,
+	}
+	return sched, sched.validate()
+}
+
+// CreateSource adds a registered scraper back to the registry after its
+// entry was deleted.
+func (h *Handler) CreateSource(c *gin.Context) {
+	var req SourceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	h.saveSource(c, req.ID, &req, true)
+}
+
+func (h *Handler) UpdateSource(c *gin.Context) {
+	var req SourceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	h.saveSource(c, c.Param("id"), &req, false)
+}
+
+func (h *Handler) saveSource(c *gin.Context, id string, req *SourceRequest, create bool) {
+	ctx := c.Request.Context()
+
+	sched, err := req.schedule(id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	src := &sources.Source{
+		ID:          id,
+		DisplayName: strings.TrimSpace(req.DisplayName),
+		LogoURL:     strings.TrimSpace(req.LogoURL),
+		Enabled:     req.Enabled,
+		Settings:    req.Settings,
+	}
+	status := http.StatusOK
+	if create {
+		err = h.manager.CreateSource(ctx, src)
+		status = http.StatusCreated
+	} else {
+		src, err = h.manager.UpdateSource(ctx, src)
+	}
+	switch {
+	case errors.Is(err, sources.ErrInvalidSource):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, sources.ErrSourceNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Source not found"})
+		return
+	case errors.Is(err, sources.ErrSourceExists):
+		c.JSON(http.StatusConflict, gin.H{"error": "Source already exists"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save source"})
+		return
+	}
+
+	if sched != nil {
+		if _, err := h.scheduler.UpdateSchedule(ctx, *sched); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update schedule"})
+			return
+		}
+	}
+	h.respondSource(c, status, src)
+}
+
+func (h *Handler) DeleteSource(c *gin.Context) {
+	err := h.manager.DeleteSource(c.Request.Context(), c.Param("id"))
+	if errors.Is(err, sources.ErrSourceNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Source not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete source"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Source deleted"})
+}
+
+func (h *Handler) GetSourceHealth(c *gin.Context) {
+	health, err := h.manager.Health(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch source health"})
+		return
+	}
+
+	c.JSON(http.StatusOK, health)
+}
+
+// maxImportSize caps the size of an uploaded import file
+const maxImportSize = 32 << 20
+
+// importFormat reads the format of an upload from the format query
+// parameter, falling back to its content type.
+func importFormat(c *gin.Context) string {
+	if format := c.Query("format"); format != "" {
+		return strings.ToLower(format)
+	}
+	switch c.ContentType() {
+	case "text/csv":
+		return FormatCSV
+	case "application/x-ndjson", "application/ndjson":
+		return FormatNDJSON
+	}
+	return ""
+}
+
+func (h *Handler) ImportJobs(c *gin.Context) {
+	format := importFormat(c)
+	if format != FormatCSV && format != FormatNDJSON {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Send CSV (text/csv) or NDJSON (application/x-ndjson), or set format=csv|ndjson"})
+		return
+	}
+
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize)
+	rows, rowErrs, err := decodeImport(body, format)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Import file is too large"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.manager.Import(c.Request.Context(), rows, rowErrs)
+	if err != nil {
+		log.Printf("❌ Job import failed: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to import jobs"})
+		return
+	}
+	log.Printf("📥 Imported %d rows: %d added, %d updated, %d unchanged, %d failed",
+		result.Rows, result.Added, result.Updated, result.Unchanged, result.Failed)
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *Handler) ExportJobs(c *gin.Context) {
+	format := strings.ToLower(c.DefaultQuery("format", FormatNDJSON))
+	contentType := map[string]string{FormatCSV: "text/csv", FormatNDJSON: "application/x-ndjson"}[format]
+	if contentType == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be csv or ndjson"})
+		return
+	}
+
+	var filter jobs.JobFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if skills := c.Query("skills"); skills != "" {
+		filter.Skills = strings.Split(skills, ",")
+	}
+	if err := filter.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Type", contentType+"; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="jobs-`+time.Now().UTC().Format("20060102")+"."+format+`"`)
+	c.Status(http.StatusOK)
+
+	w, err := newExportWriter(c.Writer, format)
+	if err != nil {
+		log.Printf("❌ Job export failed: %v", err)
+		return
+	}
+	// Headers are sent by now, so a failure can only cut the file short
+	count := 0
+	err = h.jobsRepo.Each(c.Request.Context(), &filter, func(job *jobs.Job) error {
+		if err := w.Write(job); err != nil {
+			return err
+		}
+		if count++; count%500 == 0 {
+			if err := w.Flush(); err != nil {
+				return err
+			}
+			c.Writer.Flush()
+		}
+		return nil
+	})
+	if err == nil {
+		err = w.Flush()
+	}
+	if err != nil {
+		log.Printf("❌ Job export stopped after %d jobs: %v", count, err)
+		return
+	}
+	log.Printf("📤 Exported %d jobs as %s", count, format)
+}
+
+type RunSummary struct {
+	ScrapeRun
+	ErrorRate     float64 `json:"errorRate"`
+	JobsScraped   int     `json:"jobsScraped"`
+	JobsAdded     int     `json:"jobsAdded"`
+	JobsUpdated   int     `json:"jobsUpdated"`
+	JobsUnchanged int     `json:"jobsUnchanged"`
+	JobsFailed    int     `json:"jobsFailed"`
+}
+
+func summarizeRun(run ScrapeRun) RunSummary {
+	summary := RunSummary{ScrapeRun: run, ErrorRate: run.ErrorRate()}
+	for _, src := range run.Sources {
+		if src.Result != nil {
+			summary.JobsScraped += src.Result.JobsScraped
+			summary.JobsAdded += src.Result.JobsAdded
+			summary.JobsUpdated += src.Result.JobsUpdated
+			summary.JobsUnchanged += src.Result.JobsUnchanged
+			summary.JobsFailed += src.Result.JobsFailed
+		}
+	}
+	return summary
 }
 
 func (h *Handler) GetStats(c *gin.Context) {
-	// Placeholder for admin stats
+	ctx := c.Request.Context()
+
+	limit, err := strconv.Atoi(c.DefaultQuery("runs", "10"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	totalUsers, err := h.userRepo.Count(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count users"})
+		return
+	}
+
+	jobStats, err := h.jobsRepo.Stats(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to aggregate job stats"})
+		return
+	}
+
+	runs, err := h.runRepo.Recent(ctx, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch scrape runs"})
+		return
+	}
+	recentScrapes := make([]RunSummary, len(runs))
+	for i, run := range runs {
+		recentScrapes[i] = summarizeRun(run)
+	}
+
+	var totalInteractions int64
+	for _, count := range jobStats.InteractionsByAction {
+		totalInteractions += count
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"totalUsers":        0,
-		"totalJobs":         0,
-		"totalInteractions": 0,
-		"aiUsage":           []gin.H{},
-		"recentScrapes":     []gin.H{},
+		"totalUsers":           totalUsers,
+		"totalJobs":            jobStats.ActiveJobs + jobStats.InactiveJobs,
+		"activeJobs":           jobStats.ActiveJobs,
+		"inactiveJobs":         jobStats.InactiveJobs,
+		"jobsBySource":         jobStats.JobsBySource,
+		"totalInteractions":    totalInteractions,
+		"interactionsByAction": jobStats.InteractionsByAction,
+		"recentScrapes":        recentScrapes,
 	})
 }
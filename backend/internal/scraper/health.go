@@ -0,0 +1,229 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"sync"
+	"time"
+)
+
+// CircuitState is whether a source is scraped. An open circuit skips the
+// source until its cooldown passes; the next run is then a half-open trial
+// that closes the circuit on success or reopens it on failure.
+type CircuitState string
+
+const (
+	CircuitClosed   CircuitState = "closed"
+	CircuitOpen     CircuitState = "open"
+	CircuitHalfOpen CircuitState = "half-open"
+)
+
+// SourceHealth summarises how a source's recent scrapes went.
+type SourceHealth struct {
+	Source              string       `json:"source" bson:"_id"`
+	State               CircuitState `json:"state" bson:"state"`
+	ConsecutiveFailures int          `json:"consecutiveFailures" bson:"consecutiveFailures"`
+	RetryAt             *time.Time   `json:"retryAt,omitempty" bson:"retryAt,omitempty"`
+	LastRunAt           *time.Time   `json:"lastRunAt,omitempty" bson:"lastRunAt,omitempty"`
+	LastSuccessAt       *time.Time   `json:"lastSuccessAt,omitempty" bson:"lastSuccessAt,omitempty"`
+	LastError           string       `json:"lastError,omitempty" bson:"lastError,omitempty"`
+
+	// Moving averages over recent runs
+	Runs          int     `json:"runs" bson:"runs"`
+	MeanLatencyMs float64 `json:"meanLatencyMs" bson:"meanLatencyMs"`
+	MeanJobs      float64 `json:"meanJobs" bson:"meanJobs"`
+	LastJobs      int     `json:"lastJobs" bson:"lastJobs"`
+
+	// Probable format breakage, from the last successful run's yield
+	Suspect  bool     `json:"suspect" bson:"suspect"`
+	Warnings []string `json:"warnings" bson:"warnings"`
+}
+
+const (
+	// averageWeight is how much each run moves the moving averages.
+	averageWeight = 0.3
+
+	// A run yielding less than yieldDropRatio of the average is suspect,
+	// once the average is at least minYieldBaseline jobs.
+	yieldDropRatio   = 0.5
+	minYieldBaseline = 10
+)
+
+// HealthTracker records source health and decides when circuits open.
+// Health is kept in memory and, when repo is set, in MongoDB so every
+// instance sees it.
+type HealthTracker struct {
+	repo      *HealthRepository
+	threshold int
+	cooldown  time.Duration
+
+	mu      sync.Mutex
+	sources map[string]*SourceHealth
+}
+
+func NewHealthTracker(repo *HealthRepository, threshold int, cooldown time.Duration) *HealthTracker {
+	return &HealthTracker{
+		repo:      repo,
+		threshold: threshold,
+		cooldown:  cooldown,
+		sources:   make(map[string]*SourceHealth),
+	}
+}
+
+// load returns the tracked health of source, reading it from the
+// repository the first time. The caller must hold t.mu.
+func (t *HealthTracker) load(ctx context.Context, source string) *SourceHealth {
+	if h, ok := t.sources[source]; ok {
+		return h
+	}
+	return t.refresh(ctx, source)
+}
+
+// refresh reads the stored health of source again, so failures recorded
+// by other instances are seen. The tracked health is kept when the read
+// fails or nothing is stored. The caller must hold t.mu.
+func (t *HealthTracker) refresh(ctx context.Context, source string) *SourceHealth {
+	h, ok := t.sources[source]
+	if !ok {
+		h = &SourceHealth{Source: source, State: CircuitClosed, Warnings: []string{}}
+	}
+	if t.repo != nil {
+		stored, err := t.repo.Find(ctx, source)
+		switch {
+		case err == nil && stored != nil:
+			h = stored
+		case err != nil:
+			log.Printf("⚠️ Failed to load health of %s: %v", source, err)
+		}
+	}
+	t.sources[source] = h
+	return h
+}
+
+// Allow reports whether source may be scraped now, from its latest stored
+// health. When its circuit is open it returns an error saying until when.
+func (t *HealthTracker) Allow(ctx context.Context, source string, now time.Time) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	h := t.refresh(ctx, source)
+	if h.State != CircuitOpen {
+		return nil
+	}
+	if h.RetryAt != nil && now.Before(*h.RetryAt) {
+		return fmt.Errorf("circuit open after %d consecutive failures, retrying after %s",
+			h.ConsecutiveFailures, h.RetryAt.UTC().Format(time.RFC3339))
+	}
+	h.State = CircuitHalfOpen
+	return nil
+}
+
+// YieldWarnings returns why a successful run yielding scraped jobs looks
+// like the source changed its format: no jobs at all, or far fewer than
+// usual.
+func (t *HealthTracker) YieldWarnings(ctx context.Context, source string, scraped int) []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	h := t.load(ctx, source)
+	switch {
+	case scraped == 0:
+		return []string{"no jobs scraped"}
+	case h.MeanJobs >= minYieldBaseline && float64(scraped) < h.MeanJobs*yieldDropRatio:
+		return []string{fmt.Sprintf("scraped %d jobs, down from about %.0f", scraped, h.MeanJobs)}
+	}
+	return nil
+}
+
+// Record updates a source's health with the outcome of a run. A run fails
+// when it returned errors and no jobs.
+func (t *HealthTracker) Record(ctx context.Context, result ScrapeResult) SourceHealth {
+	t.mu.Lock()
+	h := t.load(ctx, result.Source)
+
+	at := result.CompletedAt
+	h.LastRunAt = &at
+	h.Runs++
+	latency := float64(result.CompletedAt.Sub(result.StartedAt).Milliseconds())
+	h.MeanLatencyMs = movingAverage(h.MeanLatencyMs, latency, h.Runs == 1)
+
+	if result.JobsScraped == 0 && len(result.Errors) > 0 {
+		h.ConsecutiveFailures++
+		h.LastError = result.Errors[0]
+		if h.State == CircuitHalfOpen || h.ConsecutiveFailures >= t.threshold {
+			if h.State != CircuitOpen {
+				log.Printf("🔌 %s: circuit open after %d consecutive failures", h.Source, h.ConsecutiveFailures)
+			}
+			retryAt := at.Add(t.cooldown)
+			h.State = CircuitOpen
+			h.RetryAt = &retryAt
+		}
+	} else {
+		if h.State != CircuitClosed {
+			log.Printf("🔌 %s: circuit closed", h.Source)
+		}
+		h.State = CircuitClosed
+		h.ConsecutiveFailures = 0
+		h.RetryAt = nil
+		h.LastError = ""
+		h.MeanJobs = movingAverage(h.MeanJobs, float64(result.JobsScraped), h.LastSuccessAt == nil)
+		h.LastSuccessAt = &at
+		h.LastJobs = result.JobsScraped
+		h.Warnings = append([]string{}, result.Warnings...)
+		h.Suspect = len(h.Warnings) > 0
+	}
+
+	snapshot := *h
+	t.mu.Unlock()
+
+	if t.repo != nil {
+		if err := t.repo.Save(ctx, &snapshot); err != nil {
+			log.Printf("⚠️ Failed to save health of %s: %v", snapshot.Source, err)
+		}
+	}
+	return snapshot
+}
+
+// All returns the health of the given sources, in order. It reads the
+// repository so the result includes runs made by other instances.
+func (t *HealthTracker) All(ctx context.Context, sources []string) ([]SourceHealth, error) {
+	stored := map[string]SourceHealth{}
+	if t.repo != nil {
+		all, err := t.repo.All(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, h := range all {
+			stored[h.Source] = h
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	result := make([]SourceHealth, len(sources))
+	for i, source := range sources {
+		if h, ok := stored[source]; ok {
+			result[i] = h
+		} else if h, ok := t.sources[source]; ok {
+			result[i] = *h
+		} else {
+			result[i] = SourceHealth{Source: source, State: CircuitClosed, Warnings: []string{}}
+		}
+		if result[i].Warnings == nil {
+			result[i].Warnings = []string{}
+		}
+	}
+	return result, nil
+}
+
+// movingAverage folds value into an exponentially weighted average, or
+// starts it from value.
+func movingAverage(avg, value float64, first bool) float64 {
+	if first {
+		return value
+	}
+	return math.Round((avg*(1-averageWeight)+value*averageWeight)*10) / 10
+}
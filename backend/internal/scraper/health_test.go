@@ -0,0 +1,69 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	ctx := context.Background()
+	m := &ScraperManager{
+		scrapers:    []Scraper{&fakeScraper{name: "broken", err: errors.New("bad gateway")}},
+		health:      NewHealthTracker(nil, 2, time.Hour),
+		concurrency: 1,
+	}
+
+	for i := 0; i < 2; i++ {
+		if r := m.runScrapers(ctx, m.scrapers, nil, nil)[0]; r.Skipped {
+			t.Fatalf("run %d skipped before the circuit opened", i+1)
+		}
+	}
+	r := m.runScrapers(ctx, m.scrapers, nil, nil)[0]
+	if !r.Skipped || len(r.Errors) != 1 || !strings.Contains(r.Errors[0], "circuit open") {
+		t.Fatalf("third run = %+v, want it skipped", r)
+	}
+
+	health, _ := m.Health(ctx)
+	h := health[0]
+	if h.State != CircuitOpen || h.ConsecutiveFailures != 2 || h.LastError != "bad gateway" || h.RetryAt == nil {
+		t.Fatalf("health = %+v", h)
+	}
+
+	// Once the cooldown passes, one trial run is let through
+	tracker := m.health
+	if err := tracker.Allow(ctx, "broken", h.RetryAt.Add(time.Second)); err != nil {
+		t.Fatalf("Allow after cooldown = %v", err)
+	}
+	now := time.Now()
+	h = tracker.Record(ctx, ScrapeResult{Source: "broken", JobsScraped: 5, StartedAt: now, CompletedAt: now})
+	if h.State != CircuitClosed || h.ConsecutiveFailures != 0 || h.LastSuccessAt == nil {
+		t.Errorf("health after a successful trial = %+v", h)
+	}
+}
+
+func TestYieldWarnings(t *testing.T) {
+	ctx := context.Background()
+	tracker := NewHealthTracker(nil, 3, time.Hour)
+	now := time.Now()
+	for _, n := range []int{100, 120, 110} {
+		tracker.Record(ctx, ScrapeResult{Source: "feed", JobsScraped: n, StartedAt: now, CompletedAt: now})
+	}
+
+	if w := tracker.YieldWarnings(ctx, "feed", 90); len(w) != 0 {
+		t.Errorf("YieldWarnings(90) = %v, want none", w)
+	}
+	if w := tracker.YieldWarnings(ctx, "feed", 12); len(w) != 1 || !strings.Contains(w[0], "down from about") {
+		t.Errorf("YieldWarnings(12) = %v", w)
+	}
+	if w := tracker.YieldWarnings(ctx, "feed", 0); len(w) != 1 {
+		t.Errorf("YieldWarnings(0) = %v", w)
+	}
+
+	h := tracker.Record(ctx, ScrapeResult{Source: "feed", StartedAt: now, CompletedAt: now, Warnings: []string{"no jobs scraped"}})
+	if !h.Suspect || h.State != CircuitClosed {
+		t.Errorf("health after an empty run = %+v", h)
+	}
+}
@@ -0,0 +1,196 @@
+package scraper
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const userAgent = "HireSense Job Aggregator"
+
+// httpSource is the HTTP access the scrapers share. Its base URL and
+// transport can be replaced, for example to serve recorded responses in
+// tests or to go through a proxy.
+//
+// Requests are polite: they wait their turn for the host, are retried with
+// backoff when the source is busy or failing, and revalidate earlier
+// responses with If-None-Match and If-Modified-Since.
+type httpSource struct {
+	client  *http.Client
+	baseURL string
+
+	retry   *retryPolicy
+	limiter *hostLimiter
+	cache   *responseCache
+}
+
+func newHTTPSource(baseURL string) httpSource {
+	retry := defaultRetry
+	return httpSource{
+		client:  &http.Client{Timeout: 30 * time.Second},
+		baseURL: baseURL,
+		retry:   &retry,
+		limiter: newHostLimiter(0),
+		cache:   newResponseCache(nil, responseCacheBytes),
+	}
+}
+
+// setFetchPolicy replaces the retry policy, host limiter and response
+// cache, so the scrapers of one manager share them.
+func (h *httpSource) setFetchPolicy(retry *retryPolicy, limiter *hostLimiter, cache *responseCache) {
+	h.retry = retry
+	h.limiter = limiter
+	h.cache = cache
+}
+
+// SetBaseURL changes where the scraper sends its requests. Scrapers of
+// configured pages, such as career pages, keep each page's path and query
+// but send it to this host.
+func (h *httpSource) SetBaseURL(baseURL string) {
+	h.baseURL = strings.TrimRight(baseURL, "/")
+}
+
+// SetTransport replaces the transport used for every request.
+func (h *httpSource) SetTransport(rt http.RoundTripper) {
+	h.client.Transport = rt
+}
+
+// resolve returns the URL to request for target: a path is appended to
+// the base URL, and an absolute URL is moved onto the base URL's host.
+func (h *httpSource) resolve(target string) string {
+	if h.baseURL == "" {
+		return target
+	}
+	u, err := url.Parse(target)
+	if err != nil || !u.IsAbs() {
+		return h.baseURL + target
+	}
+	base, err := url.Parse(h.baseURL)
+	if err != nil {
+		return target
+	}
+	if u.Scheme == base.Scheme && u.Host == base.Host && strings.HasPrefix(u.Path, base.Path) {
+		return target
+	}
+	u.Scheme, u.Host = base.Scheme, base.Host
+	u.Path, u.RawPath = base.Path+u.Path, ""
+	return u.String()
+}
+
+// get fetches target with the scraper's User-Agent and any extra headers
+// and returns the body. Statuses other than 2xx are returned as a
+// *StatusError once retries run out; a 304 Not Modified returns the body
+// cached from the previous response.
+func (h *httpSource) get(ctx context.Context, target string, header map[string]string) ([]byte, error) {
+	target = h.resolve(target)
+	u, err := url.Parse(target)
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; ; attempt++ {
+		if err := h.limiter.wait(ctx, u.Host); err != nil {
+			return nil, err
+		}
+
+		body, wait, err := h.attempt(ctx, target, header)
+		if err == nil {
+			return body, nil
+		}
+		if wait < 0 || attempt >= h.retry.retries || ctx.Err() != nil {
+			return nil, err
+		}
+		if wait == 0 {
+			wait = h.retry.backoff(attempt)
+		} else {
+			h.limiter.delay(u.Host, wait)
+		}
+		if err := sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// attempt makes one request. On failure it also returns how long to wait
+// before retrying: 0 to back off as usual, or -1 not to retry.
+func (h *httpSource) attempt(ctx context.Context, target string, header map[string]string) ([]byte, time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", target, nil)
+	if err != nil {
+		return nil, -1, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	cached, hasCached := h.cache.get(ctx, target)
+	if hasCached {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && hasCached:
+		return cached.Body, 0, nil
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+		err := &StatusError{URL: target, StatusCode: resp.StatusCode, Status: resp.Status}
+		if !retryable(resp.StatusCode) {
+			return nil, -1, err
+		}
+		if wait, ok := retryAfter(resp.Header, time.Now()); ok {
+			if wait > h.retry.maxRetryAfter {
+				return nil, -1, fmt.Errorf("%w (retry after %s)", err, wait)
+			}
+			return nil, max(wait, time.Millisecond), err
+		}
+		return nil, 0, err
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize+1))
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(body) > maxResponseSize {
+		return nil, -1, fmt.Errorf("GET %s: %w", target, errResponseTooLarge)
+	}
+	h.cache.put(ctx, target, resp.Header, body)
+	return body, 0, nil
+}
+
+// getJSON fetches target and decodes its JSON body into v. A body that is
+// not JSON, like an HTML error page, is reported with its first bytes.
+func (h *httpSource) getJSON(ctx context.Context, target string, header map[string]string, v interface{}) error {
+	body, err := h.get(ctx, target, header)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("decoding %s: %w (response starts %q)", h.resolve(target), err, snippet(body))
+	}
+	return nil
+}
+
+// snippet returns the start of a response body for error messages.
+func snippet(body []byte) string {
+	body = bytes.TrimSpace(body)
+	if len(body) > 80 {
+		return string(body[:80]) + "…"
+	}
+	return string(body)
+}
@@ -0,0 +1,367 @@
+package scraper
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/hiresense/backend/internal/jobs"
+	"github.com/hiresense/backend/internal/sources"
+	"golang.org/x/net/html"
+)
+
+// JobPosting Scraper extracts schema.org JobPosting objects embedded as
+// JSON-LD in career pages, so new boards can be added with config alone.
+type JobPostingScraper struct {
+	httpSource
+	urls []string
+}
+
+func NewJobPostingScraper(urls []string) *JobPostingScraper {
+	return &JobPostingScraper{
+		httpSource: newHTTPSource(""),
+		urls:       urls,
+	}
+}
+
+func (s *JobPostingScraper) Name() string { return "CareerPages" }
+
+func (s *JobPostingScraper) Settings() sources.Settings {
+	return sources.Settings{"urls": s.urls}
+}
+
+func (s *JobPostingScraper) Configure(settings sources.Settings) { s.urls = settings["urls"] }
+
+func (s *JobPostingScraper) Scrape(ctx context.Context) ([]jobs.Job, error) {
+	var (
+		result []jobs.Job
+		errs   []error
+	)
+
+	if len(s.urls) == 0 {
+		return nil, errors.New("no career page URLs configured")
+	}
+	for _, pageURL := range s.urls {
+		pageJobs, err := s.scrapePage(ctx, pageURL)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", pageURL, err))
+			continue
+		}
+		result = append(result, pageJobs...)
+	}
+
+	return result, errors.Join(errs...)
+}
+
+func (s *JobPostingScraper) scrapePage(ctx context.Context, pageURL string) ([]jobs.Job, error) {
+	body, err := s.get(ctx, pageURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	postings, err := extractJobPostings(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]jobs.Job, 0, len(postings))
+	derived := make(map[string]int)
+	for _, posting := range postings {
+		if job, ok := jobPostingToJob(pageURL, posting, derived); ok {
+			result = append(result, job)
+		}
+	}
+	return result, nil
+}
+
+// extractJobPostings returns every JobPosting object found in the
+// application/ld+json script blocks of an HTML document.
+func extractJobPostings(r io.Reader) ([]map[string]interface{}, error) {
+	doc, err := html.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var postings []map[string]interface{}
+	var visit func(n *html.Node)
+	visit = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.Data == "script" && isJSONLD(n) && n.FirstChild != nil {
+			var data interface{}
+			// Malformed blocks are common on career pages and are skipped
+			if err := json.Unmarshal([]byte(n.FirstChild.Data), &data); err == nil {
+				postings = append(postings, findJobPostings(data)...)
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			visit(c)
+		}
+	}
+	visit(doc)
+
+	return postings, nil
+}
+
+func isJSONLD(n *html.Node) bool {
+	for _, attr := range n.Attr {
+		if attr.Key == "type" && strings.EqualFold(strings.TrimSpace(attr.Val), "application/ld+json") {
+			return true
+		}
+	}
+	return false
+}
+
+// findJobPostings walks arrays and @graph containers looking for JobPosting objects.
+func findJobPostings(v interface{}) []map[string]interface{} {
+	switch val := v.(type) {
+	case []interface{}:
+		var result []map[string]interface{}
+		for _, item := range val {
+			result = append(result, findJobPostings(item)...)
+		}
+		return result
+	case map[string]interface{}:
+		if hasType(val, "JobPosting") {
+			return []map[string]interface{}{val}
+		}
+		if graph, ok := val["@graph"]; ok {
+			return findJobPostings(graph)
+		}
+	}
+	return nil
+}
+
+func hasType(m map[string]interface{}, want string) bool {
+	for _, t := range stringList(m["@type"]) {
+		if t == want || strings.HasSuffix(t, "/"+want) {
+			return true
+		}
+	}
+	return false
+}
+
+// jobPostingToJob converts a JobPosting found on pageURL. derived counts
+// the IDs derived so far on the page, so repeated openings stay distinct.
+func jobPostingToJob(pageURL string, p map[string]interface{}, derived map[string]int) (jobs.Job, bool) {
+	job := jobs.Job{
+		Title:          strings.TrimSpace(getString(p, "title")),
+		Company:        organizationName(p["hiringOrganization"]),
+		Description:    html.UnescapeString(getString(p, "description")),
+		Salary:         formatBaseSalary(p["baseSalary"]),
+		Location:       jobPostingLocation(p),
+		URL:            postingURL(pageURL, getString(p, "url")),
+		Source:         "CareerPages",
+		Skills:         stringList(p["skills"]),
+		EmploymentType: strings.Join(stringList(p["employmentType"]), " "),
+		IsActive:       true,
+	}
+
+	// Prefer the posting's own identifier; otherwise derive one that stays
+	// stable across runs from the page, title and location, numbering
+	// openings that share all three in page order.
+	job.SourceID = identifierValue(p["identifier"])
+	if job.SourceID != "" {
+		job.SourceID = job.Company + "/" + job.SourceID
+	} else if job.URL != pageURL {
+		job.SourceID = job.URL
+	} else {
+		sum := sha1.Sum([]byte(pageURL + "|" + job.Title + "|" + job.Location))
+		id := hex.EncodeToString(sum[:])
+		derived[id]++
+		if n := derived[id]; n > 1 {
+			id = fmt.Sprintf("%s-%d", id, n)
+		}
+		job.SourceID = id
+	}
+
+	if t, ok := parsePostedDate(getString(p, "datePosted")); ok {
+		job.PostedAt = t
+	}
+	if t, ok := parseDate(getString(p, "validThrough")); ok {
+		job.ValidThrough = &t
+	}
+
+	if job.Title == "" || job.Company == "" {
+		return jobs.Job{}, false
+	}
+	return job, true
+}
+
+// postingURL resolves a posting's url against the page it was found on.
+// A missing or unparseable url falls back to the page.
+func postingURL(pageURL, ref string) string {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return pageURL
+	}
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return pageURL
+	}
+	u, err := base.Parse(ref)
+	if err != nil {
+		return pageURL
+	}
+	return u.String()
+}
+
+func organizationName(v interface{}) string {
+	switch org := v.(type) {
+	case string:
+		return strings.TrimSpace(org)
+	case map[string]interface{}:
+		return strings.TrimSpace(getString(org, "name"))
+	case []interface{}:
+		if len(org) > 0 {
+			return organizationName(org[0])
+		}
+	}
+	return ""
+}
+
+func identifierValue(v interface{}) string {
+	switch id := v.(type) {
+	case string:
+		return id
+	case float64:
+		return fmt.Sprintf("%.0f", id)
+	case map[string]interface{}:
+		return identifierValue(id["value"])
+	}
+	return ""
+}
+
+// jobPostingLocation combines jobLocationType, applicantLocationRequirements
+// and jobLocation into a single location string.
+func jobPostingLocation(p map[string]interface{}) string {
+	remote := false
+	for _, t := range stringList(p["jobLocationType"]) {
+		if strings.EqualFold(t, "TELECOMMUTE") {
+			remote = true
+		}
+	}
+
+	var places []string
+	for _, req := range objectList(p["applicantLocationRequirements"]) {
+		if name := getString(req, "name"); name != "" {
+			places = append(places, name)
+		}
+	}
+	if len(places) == 0 && !remote {
+		for _, loc := range objectList(p["jobLocation"]) {
+			if place := postalAddress(loc["address"]); place != "" {
+				places = append(places, place)
+			}
+		}
+	}
+
+	switch {
+	case remote && len(places) > 0:
+		return "Remote (" + strings.Join(places, ", ") + ")"
+	case remote:
+		return "Remote"
+	case len(places) > 0:
+		return strings.Join(places, "; ")
+	}
+	return "Remote"
+}
+
+func postalAddress(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	addr, ok := v.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+
+	var parts []string
+	for _, key := range []string{"addressLocality", "addressRegion", "addressCountry"} {
+		var part string
+		if country, ok := addr[key].(map[string]interface{}); ok {
+			part = getString(country, "name")
+		} else {
+			part = getString(addr, key)
+		}
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatBaseSalary renders a MonetaryAmount such as
+// {"currency":"USD","value":{"minValue":1,"maxValue":2,"unitText":"YEAR"}}.
+func formatBaseSalary(v interface{}) string {
+	amount, ok := v.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	currency := getString(amount, "currency")
+
+	var low, high float64
+	var unit string
+	switch value := amount["value"].(type) {
+	case float64:
+		low = value
+	case map[string]interface{}:
+		low, _ = value["minValue"].(float64)
+		high, _ = value["maxValue"].(float64)
+		if single, ok := value["value"].(float64); ok && low == 0 {
+			low = single
+		}
+		unit = getString(value, "unitText")
+	}
+	if low == 0 && high == 0 {
+		return ""
+	}
+
+	salary := fmt.Sprintf("%.0f", low)
+	if high > low {
+		salary += fmt.Sprintf(" - %.0f", high)
+	}
+	if currency != "" {
+		salary = currency + " " + salary
+	}
+	if unit != "" {
+		salary += " per " + strings.ToLower(unit)
+	}
+	return salary
+}
+
+// stringList accepts a JSON string or array of strings.
+func stringList(v interface{}) []string {
+	switch val := v.(type) {
+	case string:
+		if val == "" {
+			return []string{}
+		}
+		return parseSkills(val)
+	case []interface{}:
+		return parseTagsArray(val)
+	}
+	return []string{}
+}
+
+// objectList accepts a single JSON object or an array of objects.
+func objectList(v interface{}) []map[string]interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		return []map[string]interface{}{val}
+	case []interface{}:
+		result := make([]map[string]interface{}, 0, len(val))
+		for _, item := range val {
+			if m, ok := item.(map[string]interface{}); ok {
+				result = append(result, m)
+			}
+		}
+		return result
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestJobPostingScraper(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, "testdata/jobposting_careers.html")
+	}))
+	defer srv.Close()
+
+	s := NewJobPostingScraper([]string{srv.URL + "/careers"})
+	s.client = srv.Client()
+
+	got, err := s.Scrape(context.Background())
+	if err != nil {
+		t.Fatalf("Scrape: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d jobs, want 2", len(got))
+	}
+
+	staff := got[0]
+	if staff.Title != "Staff Platform Engineer" || staff.Company != "Initech" {
+		t.Errorf("title/company = %q/%q", staff.Title, staff.Company)
+	}
+	if staff.SourceID != "Initech/ENG-042" || staff.URL != "https://initech.example/careers/eng-042" {
+		t.Errorf("sourceId/url = %q/%q", staff.SourceID, staff.URL)
+	}
+	if staff.Location != "Remote (USA, Canada)" {
+		t.Errorf("location = %q", staff.Location)
+	}
+	if staff.Salary != "USD 180000 - 220000 per year" {
+		t.Errorf("salary = %q", staff.Salary)
+	}
+	if staff.EmploymentType != "FULL_TIME" {
+		t.Errorf("employmentType = %q", staff.EmploymentType)
+	}
+	if staff.Description != "<p>Own our Kubernetes platform.</p>" {
+		t.Errorf("description = %q", staff.Description)
+	}
+	if want := []string{"Kubernetes", "Go"}; !reflect.DeepEqual(staff.Skills, want) {
+		t.Errorf("skills = %v, want %v", staff.Skills, want)
+	}
+	if !staff.PostedAt.Equal(time.Date(2024, 10, 10, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("postedAt = %v", staff.PostedAt)
+	}
+	if staff.ValidThrough == nil || !staff.ValidThrough.Equal(time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC)) {
+		t.Errorf("validThrough = %v", staff.ValidThrough)
+	}
+
+	office := got[1]
+	if office.Location != "Austin, TX, US" || office.Salary != "USD 32 per hour" {
+		t.Errorf("location/salary = %q/%q", office.Location, office.Salary)
+	}
+	if office.SourceID == "" || office.URL != srv.URL+"/careers" {
+		t.Errorf("sourceId/url = %q/%q", office.SourceID, office.URL)
+	}
+}
+
+func TestJobPostingDerivedSourceIDs(t *testing.T) {
+	const page = "https://initech.example/careers"
+	posting := func(location string) map[string]interface{} {
+		return map[string]interface{}{
+			"title":              "Support Engineer",
+			"hiringOrganization": "Initech",
+			"jobLocation":        map[string]interface{}{"address": map[string]interface{}{"addressLocality": location}},
+		}
+	}
+
+	derived := make(map[string]int)
+	var ids []string
+	for _, location := range []string{"Austin", "Berlin", "Austin"} {
+		job, ok := jobPostingToJob(page, posting(location), derived)
+		if !ok {
+			t.Fatalf("posting in %s was dropped", location)
+		}
+		ids = append(ids, job.SourceID)
+	}
+	if ids[0] == ids[1] || ids[0] == ids[2] || ids[1] == ids[2] {
+		t.Errorf("sourceIds = %v, want each opening distinct", ids)
+	}
+
+	// The next run derives the same IDs
+	again, _ := jobPostingToJob(page, posting("Austin"), make(map[string]int))
+	if again.SourceID != ids[0] {
+		t.Errorf("sourceId = %q on the next run, want %q", again.SourceID, ids[0])
+	}
+}
+
+func TestJobPostingURL(t *testing.T) {
+	const page = "https://initech.example/careers"
+	tests := []struct{ url, want string }{
+		{"", page},
+		{"  ", page},
+		{"/jobs/123", "https://initech.example/jobs/123"},
+		{"jobs/123", "https://initech.example/jobs/123"},
+		{"https://boards.example/initech/123", "https://boards.example/initech/123"},
+	}
+	for _, tt := range tests {
+		job, ok := jobPostingToJob(page, map[string]interface{}{
+			"title":              "Support Engineer",
+			"hiringOrganization": "Initech",
+			"url":                tt.url,
+		}, make(map[string]int))
+		if !ok || job.URL != tt.want || job.SourceID == "" {
+			t.Errorf("url %q: url/sourceId = %q/%q, want url %q and a sourceId", tt.url, job.URL, job.SourceID, tt.want)
+		}
+	}
+}
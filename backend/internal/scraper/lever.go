@@ -0,0 +1,165 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/hiresense/backend/internal/jobs"
+	"github.com/hiresense/backend/internal/sources"
+)
+
+// Lever Scraper reads the public postings API of each configured company.
+// Companies are board slugs, optionally with a display name as in
+// "acme-corp=Acme Corp"; the API does not return the company's name.
+type LeverScraper struct {
+	httpSource
+	companies []string
+}
+
+func NewLeverScraper(companies []string) *LeverScraper {
+	return &LeverScraper{
+		httpSource: newHTTPSource("https://api.lever.co/v0/postings"),
+		companies:  companies,
+	}
+}
+
+func (s *LeverScraper) Name() string { return "Lever" }
+
+func (s *LeverScraper) Settings() sources.Settings {
+	return sources.Settings{"companies": s.companies}
+}
+
+func (s *LeverScraper) Configure(settings sources.Settings) { s.companies = settings["companies"] }
+
+type leverPosting struct {
+	ID               string `json:"id"`
+	Text             string `json:"text"`
+	HostedURL        string `json:"hostedUrl"`
+	CreatedAt        int64  `json:"createdAt"`
+	DescriptionPlain string `json:"descriptionPlain"`
+	Description      string `json:"description"`
+	WorkplaceType    string `json:"workplaceType"`
+	Categories       struct {
+		Commitment string `json:"commitment"`
+		Department string `json:"department"`
+		Location   string `json:"location"`
+		Team       string `json:"team"`
+	} `json:"categories"`
+	Lists []struct {
+		Text    string `json:"text"`
+		Content string `json:"content"`
+	} `json:"lists"`
+	SalaryRange *struct {
+		Min      float64 `json:"min"`
+		Max      float64 `json:"max"`
+		Currency string  `json:"currency"`
+		Interval string  `json:"interval"`
+	} `json:"salaryRange"`
+}
+
+func (s *LeverScraper) Scrape(ctx context.Context) ([]jobs.Job, error) {
+	var (
+		result []jobs.Job
+		errs   []error
+	)
+
+	if len(s.companies) == 0 {
+		return nil, errors.New("no companies configured")
+	}
+	for _, company := range s.companies {
+		slug, name := leverCompany(company)
+		postings, err := s.fetchCompany(ctx, slug)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", slug, err))
+			continue
+		}
+		for _, p := range postings {
+			if job, ok := leverToJob(slug, name, p); ok {
+				result = append(result, job)
+			}
+		}
+	}
+
+	return result, errors.Join(errs...)
+}
+
+func (s *LeverScraper) fetchCompany(ctx context.Context, company string) ([]leverPosting, error) {
+	var postings []leverPosting
+	if err := s.getJSON(ctx, "/"+url.PathEscape(company)+"?mode=json", nil, &postings); err != nil {
+		return nil, err
+	}
+	return postings, nil
+}
+
+// leverCompany splits a configured company into its board slug and display
+// name. Without a name the slug is title-cased, so "acme-corp" reads
+// "Acme Corp".
+func leverCompany(entry string) (slug, name string) {
+	slug, name, _ = strings.Cut(entry, "=")
+	slug, name = strings.TrimSpace(slug), strings.TrimSpace(name)
+	if name != "" {
+		return slug, name
+	}
+	words := strings.FieldsFunc(slug, func(r rune) bool { return r == '-' || r == '_' })
+	for i, w := range words {
+		r, size := utf8.DecodeRuneInString(w)
+		words[i] = string(unicode.ToUpper(r)) + w[size:]
+	}
+	return slug, strings.Join(words, " ")
+}
+
+func leverToJob(slug, company string, p leverPosting) (jobs.Job, bool) {
+	if p.ID == "" || p.Text == "" {
+		return jobs.Job{}, false
+	}
+
+	description := p.Description
+	for _, list := range p.Lists {
+		description += "<h3>" + list.Text + "</h3><ul>" + list.Content + "</ul>"
+	}
+
+	location := p.Categories.Location
+	if strings.EqualFold(p.WorkplaceType, "remote") && !strings.Contains(strings.ToLower(location), "remote") {
+		location = strings.TrimSpace("Remote " + location)
+	}
+	if location == "" {
+		location = "Remote"
+	}
+
+	job := jobs.Job{
+		Title:          p.Text,
+		Company:        company,
+		Description:    description,
+		Location:       location,
+		URL:            p.HostedURL,
+		Source:         "Lever",
+		SourceID:       slug + "/" + p.ID,
+		Skills:         []string{},
+		EmploymentType: p.Categories.Commitment,
+		IsActive:       true,
+	}
+
+	if p.SalaryRange != nil && p.SalaryRange.Max > 0 {
+		job.Salary = fmt.Sprintf("%s %.0f - %.0f %s",
+			p.SalaryRange.Currency, p.SalaryRange.Min, p.SalaryRange.Max, leverInterval(p.SalaryRange.Interval))
+	}
+
+	if p.CreatedAt > 0 {
+		job.PostedAt = time.UnixMilli(p.CreatedAt).UTC()
+	}
+
+	return job, true
+}
+
+// leverInterval turns Lever's "per-year-salary" style intervals into "per year".
+func leverInterval(interval string) string {
+	interval = strings.TrimSuffix(interval, "-salary")
+	interval = strings.TrimSuffix(interval, "-wage")
+	return strings.ReplaceAll(interval, "-", " ")
+}
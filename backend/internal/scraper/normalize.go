@@ -0,0 +1,56 @@
+package scraper
+
+import (
+	"strings"
+
+	"github.com/hiresense/backend/internal/jobs"
+	"github.com/hiresense/backend/internal/location"
+	"github.com/hiresense/backend/internal/sanitize"
+	"github.com/hiresense/backend/internal/skills"
+)
+
+// normalizeJobs fills in the structured fields derived from what the
+// scrapers return, before the jobs are stored.
+func normalizeJobs(list []jobs.Job) {
+	for i := range list {
+		job := &list[i]
+
+		job.Description = sanitize.HTML(job.Description)
+		job.DescriptionText = sanitize.Text(job.Description)
+		job.DescriptionMarkdown = sanitize.Markdown(job.Description)
+
+		tagged := skills.NormalizeAll(job.Skills)
+		inferred := []string{}
+		for _, skill := range skills.Extract(job.Title + "\n" + job.DescriptionText) {
+			if !containsFold(tagged, skill) {
+				inferred = append(inferred, skill)
+			}
+		}
+		job.TagSkills = tagged
+		job.InferredSkills = inferred
+		job.Skills = append(append([]string{}, tagged...), inferred...)
+
+		if job.SalaryInfo == nil && job.Salary != "" {
+			job.SalaryInfo = jobs.ParseSalary(job.Salary)
+		}
+
+		job.LocationInfo = location.Parse(job.Location)
+		job.DateUnknown = job.PostedAt.IsZero()
+
+		if !jobs.IsSeniority(job.Seniority) {
+			job.Seniority = jobs.InferSeniority(job.Title, tagged, job.DescriptionText)
+		}
+		if job.EmploymentType = jobs.NormalizeEmploymentType(job.EmploymentType); job.EmploymentType == "" {
+			job.EmploymentType = jobs.InferEmploymentType(job.Title, tagged, job.DescriptionText)
+		}
+	}
+}
+
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+package scraper
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hiresense/backend/internal/jobs"
+)
+
+func TestNormalizeJobs(t *testing.T) {
+	list := []jobs.Job{{
+		Title:       "Senior Backend Engineer (Golang)",
+		Description: "<p>You will build APIs with PostgreSQL and deploy to AWS. This is a full-time role.</p>",
+		Skills:      []string{"golang", "postgres", "Remote"},
+		Salary:      "$120k - $150k",
+		Location:    "Remote - Europe",
+	}}
+	normalizeJobs(list)
+	job := list[0]
+
+	if want := []string{"Go", "PostgreSQL", "Remote"}; !reflect.DeepEqual(job.TagSkills, want) {
+		t.Errorf("tagSkills = %v, want %v", job.TagSkills, want)
+	}
+	if want := []string{"AWS"}; !reflect.DeepEqual(job.InferredSkills, want) {
+		t.Errorf("inferredSkills = %v, want %v", job.InferredSkills, want)
+	}
+	if want := []string{"Go", "PostgreSQL", "Remote", "AWS"}; !reflect.DeepEqual(job.Skills, want) {
+		t.Errorf("skills = %v, want %v", job.Skills, want)
+	}
+	if job.SalaryInfo == nil || job.SalaryInfo.MaxUSD != 150000 {
+		t.Errorf("salaryInfo = %+v", job.SalaryInfo)
+	}
+	if job.Seniority != jobs.SenioritySenior || job.EmploymentType != jobs.EmploymentFullTime {
+		t.Errorf("seniority/employmentType = %q/%q", job.Seniority, job.EmploymentType)
+	}
+	if job.LocationInfo == nil || !reflect.DeepEqual(job.LocationInfo.Regions, []string{"Europe"}) {
+		t.Errorf("locationInfo = %+v", job.LocationInfo)
+	}
+}
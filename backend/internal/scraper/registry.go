@@ -0,0 +1,194 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/hiresense/backend/internal/sources"
+)
+
+var ErrNoEnabledSources = errors.New("no enabled sources to scrape")
+
+// Configurable is a scraper with source-specific settings. The settings
+// are kept in the source registry and applied before each run.
+type Configurable interface {
+	Scraper
+	// Settings returns every setting the scraper accepts with its value.
+	Settings() sources.Settings
+	Configure(settings sources.Settings)
+}
+
+type sourceInfo struct {
+	displayName string
+	logoURL     string
+	// requires names the setting the source cannot run without
+	requires string
+}
+
+// knownSources describes the compiled-in sources for the registry.
+var knownSources = map[string]sourceInfo{
+	"RemoteOK":       {displayName: "Remote OK", logoURL: "https://remoteok.com/favicon.ico"},
+	"Remotive":       {displayName: "Remotive", logoURL: "https://remotive.com/favicon.ico"},
+	"WeWorkRemotely": {displayName: "We Work Remotely", logoURL: "https://weworkremotely.com/favicon.ico"},
+	"HackerNews":     {displayName: "Hacker News", logoURL: "https://news.ycombinator.com/favicon.ico"},
+	"Lever":          {displayName: "Lever", logoURL: "https://www.lever.co/favicon.ico", requires: "companies"},
+	"Greenhouse":     {displayName: "Greenhouse", logoURL: "https://www.greenhouse.com/favicon.ico", requires: "boards"},
+	"CareerPages":    {displayName: "Career pages", requires: "urls"},
+}
+
+// SeedSources adds every scraper missing from the registry, with the
+// settings it was configured with. Sources whose required settings are
+// empty start disabled, as do declarative sources until an admin enables
+// them.
+func (m *ScraperManager) SeedSources(ctx context.Context) error {
+	if m.registry == nil {
+		return nil
+	}
+	defaults := make([]sources.Source, len(m.scrapers))
+	for i, s := range m.scrapers {
+		info, ok := knownSources[s.Name()]
+		if !ok {
+			info.displayName = s.Name()
+		}
+		settings := sources.Settings{}
+		if c, ok := s.(Configurable); ok {
+			settings, _ = c.Settings().Clean(c.Settings())
+		}
+		_, declarative := s.(*DefinitionScraper)
+		defaults[i] = sources.Source{
+			ID:          s.Name(),
+			DisplayName: info.displayName,
+			LogoURL:     info.logoURL,
+			Enabled:     !declarative && (info.requires == "" || len(settings[info.requires]) > 0),
+			Settings:    settings,
+		}
+	}
+	return m.registry.Seed(ctx, defaults)
+}
+
+// scraper returns the registered scraper with the given name.
+func (m *ScraperManager) scraper(name string) Scraper {
+	for _, s := range m.scrapers {
+		if s.Name() == name {
+			return s
+		}
+	}
+	return nil
+}
+
+// checkSource validates a registry entry against its scraper, cleaning
+// its settings and filling in a missing display name.
+func (m *ScraperManager) checkSource(src *sources.Source) error {
+	s := m.scraper(src.ID)
+	if s == nil {
+		return fmt.Errorf("%w: no scraper named %q", sources.ErrInvalidSource, src.ID)
+	}
+
+	accepted := sources.Settings{}
+	if c, ok := s.(Configurable); ok {
+		accepted = c.Settings()
+	}
+	settings, err := src.Settings.Clean(accepted)
+	if err != nil {
+		return err
+	}
+	src.Settings = settings
+
+	info := knownSources[src.ID]
+	if src.DisplayName == "" {
+		src.DisplayName = info.displayName
+		if src.DisplayName == "" {
+			src.DisplayName = src.ID
+		}
+	}
+	if src.Enabled && info.requires != "" && len(settings[info.requires]) == 0 {
+		return fmt.Errorf("%w: %s needs %q to be enabled", sources.ErrInvalidSource, src.ID, info.requires)
+	}
+	if src.LogoURL != "" {
+		if u, err := url.Parse(src.LogoURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: logoUrl must be an http(s) URL", sources.ErrInvalidSource)
+		}
+	}
+	return nil
+}
+
+func (m *ScraperManager) ListSources(ctx context.Context) ([]sources.Source, error) {
+	return m.registry.All(ctx)
+}
+
+func (m *ScraperManager) GetSource(ctx context.Context, id string) (*sources.Source, error) {
+	return m.registry.FindByID(ctx, id)
+}
+
+// CreateSource registers a scraper again after its entry was deleted.
+// Until then, seeding leaves it out.
+func (m *ScraperManager) CreateSource(ctx context.Context, src *sources.Source) error {
+	if err := m.checkSource(src); err != nil {
+		return err
+	}
+	return m.registry.Create(ctx, src)
+}
+
+func (m *ScraperManager) UpdateSource(ctx context.Context, src *sources.Source) (*sources.Source, error) {
+	if err := m.checkSource(src); err != nil {
+		return nil, err
+	}
+	return m.registry.Update(ctx, src)
+}
+
+// DeleteSource removes a source from the registry, which stops it being
+// scraped. It stays deleted across restarts until CreateSource registers
+// it again.
+func (m *ScraperManager) DeleteSource(ctx context.Context, id string) error {
+	return m.registry.Delete(ctx, id)
+}
+
+// loadRegistry returns the registry entries by ID, or nil when the
+// manager has no registry.
+func (m *ScraperManager) loadRegistry(ctx context.Context) (map[string]sources.Source, error) {
+	if m.registry == nil {
+		return nil, nil
+	}
+	all, err := m.registry.All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading source registry: %w", err)
+	}
+	entries := make(map[string]sources.Source, len(all))
+	for _, src := range all {
+		entries[src.ID] = src
+	}
+	return entries, nil
+}
+
+// configure drops the scrapers that are disabled or missing from the
+// registry and applies the settings of the rest. It must not run while a
+// scrape is in progress.
+func configure(scrapers []Scraper, registry map[string]sources.Source) []Scraper {
+	if registry == nil {
+		return scrapers
+	}
+	var enabled []Scraper
+	for _, s := range scrapers {
+		src, ok := registry[s.Name()]
+		if !ok || !src.Enabled {
+			continue
+		}
+		if c, ok := s.(Configurable); ok {
+			c.Configure(knownSettings(src.Settings, c.Settings()))
+		}
+		enabled = append(enabled, s)
+	}
+	return enabled
+}
+
+// knownSettings keeps the settings a scraper accepts, in case one was
+// stored before the scraper dropped it.
+func knownSettings(settings, accepted sources.Settings) sources.Settings {
+	known := sources.Settings{}
+	for key := range accepted {
+		known[key] = settings[key]
+	}
+	return known
+}
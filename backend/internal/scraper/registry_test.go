@@ -0,0 +1,61 @@
+package scraper
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hiresense/backend/internal/sources"
+)
+
+func TestCheckSource(t *testing.T) {
+	m := &ScraperManager{scrapers: []Scraper{NewRemoteOKScraper(), NewLeverScraper(nil)}}
+
+	src := &sources.Source{ID: "Lever", Enabled: true, Settings: sources.Settings{"companies": {" acme ", ""}}}
+	if err := m.checkSource(src); err != nil {
+		t.Fatalf("checkSource: %v", err)
+	}
+	if src.DisplayName != "Lever" || !reflect.DeepEqual(src.Settings["companies"], []string{"acme"}) {
+		t.Errorf("checked source = %+v", src)
+	}
+
+	invalid := []*sources.Source{
+		{ID: "Unknown"},
+		{ID: "Lever", Enabled: true},
+		{ID: "Lever", Settings: sources.Settings{"boards": {"globex"}}},
+		{ID: "RemoteOK", Settings: sources.Settings{"feeds": {"/api"}}},
+		{ID: "RemoteOK", LogoURL: "javascript:alert(1)"},
+	}
+	for _, src := range invalid {
+		if err := m.checkSource(src); !errors.Is(err, sources.ErrInvalidSource) {
+			t.Errorf("checkSource(%+v) = %v, want ErrInvalidSource", src, err)
+		}
+	}
+}
+
+func TestConfigure(t *testing.T) {
+	lever := NewLeverScraper(nil)
+	wwr := NewWeWorkRemotelyScraper()
+	scrapers := []Scraper{NewRemoteOKScraper(), lever, wwr, NewRemotiveScraper()}
+
+	got := configure(scrapers, map[string]sources.Source{
+		"RemoteOK":       {ID: "RemoteOK", Enabled: false},
+		"Lever":          {ID: "Lever", Enabled: true, Settings: sources.Settings{"companies": {"acme"}, "retired": {"x"}}},
+		"WeWorkRemotely": {ID: "WeWorkRemotely", Enabled: true},
+	})
+
+	// RemoteOK is disabled and Remotive has no registry entry
+	if len(got) != 2 || got[0] != lever || got[1] != wwr {
+		t.Fatalf("configure kept %v", got)
+	}
+	if !reflect.DeepEqual(lever.companies, []string{"acme"}) {
+		t.Errorf("lever companies = %v", lever.companies)
+	}
+	if !reflect.DeepEqual(wwr.feeds, defaultWWRFeeds) {
+		t.Errorf("wwr feeds = %v, want the defaults", wwr.feeds)
+	}
+
+	if got := configure(scrapers, nil); len(got) != len(scrapers) {
+		t.Errorf("without a registry configure kept %d of %d scrapers", len(got), len(scrapers))
+	}
+}
@@ -0,0 +1,189 @@
+package scraper
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hiresense/backend/internal/jobs"
+)
+
+var (
+	record = flag.Bool("record", false, "record responses from the live sources into testdata/cassettes")
+	update = flag.Bool("update", false, "rewrite the golden files in testdata/golden")
+)
+
+// interaction is one recorded HTTP exchange.
+type interaction struct {
+	Method      string      `json:"method"`
+	URL         string      `json:"url"`
+	Status      int         `json:"status"`
+	ContentType string      `json:"contentType,omitempty"`
+	Header      http.Header `json:"header,omitempty"`
+	Body        string      `json:"body"`
+}
+
+// volatileHeaders change on every response, or identify the session that
+// recorded it, so they are left out of cassettes.
+var volatileHeaders = []string{
+	"Age", "Alt-Svc", "Cf-Cache-Status", "Cf-Ray", "Date", "Expires", "Nel",
+	"Report-To", "Server-Timing", "Set-Cookie", "X-Amz-Cf-Id", "X-Cache",
+	"X-Request-Id", "X-Runtime", "X-Served-By", "X-Timer",
+}
+
+// cassette is a RoundTripper that replays the exchanges stored in
+// testdata/cassettes/<name>.json. With -record it sends requests to the
+// real sources instead and saves their responses when the test ends.
+type cassette struct {
+	t    *testing.T
+	path string
+
+	mu           sync.Mutex
+	interactions []interaction
+}
+
+func newCassette(t *testing.T, name string) *cassette {
+	t.Helper()
+	c := &cassette{t: t, path: filepath.Join("testdata", "cassettes", name+".json")}
+	if *record {
+		t.Cleanup(c.save)
+		return c
+	}
+
+	data, err := os.ReadFile(c.path)
+	if errors.Is(err, os.ErrNotExist) {
+		t.Skipf("no cassette at %s (run the test with -record to create it)", c.path)
+	}
+	if err != nil {
+		t.Fatalf("reading cassette: %v", err)
+	}
+	if err := json.Unmarshal(data, &c.interactions); err != nil {
+		t.Fatalf("parsing %s: %v", c.path, err)
+	}
+	return c
+}
+
+func (c *cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	if *record {
+		return c.recordRoundTrip(req)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, in := range c.interactions {
+		if in.Method == req.Method && in.URL == req.URL.String() {
+			header := in.Header.Clone()
+			if header == nil {
+				header = http.Header{}
+			}
+			if in.ContentType != "" {
+				header.Set("Content-Type", in.ContentType)
+			}
+			return &http.Response{
+				StatusCode: in.Status,
+				Status:     fmt.Sprintf("%d %s", in.Status, http.StatusText(in.Status)),
+				Header:     header,
+				Body:       io.NopCloser(strings.NewReader(in.Body)),
+				Request:    req,
+			}, nil
+		}
+	}
+	c.t.Errorf("%s: no recorded response for %s %s", c.path, req.Method, req.URL)
+	return nil, fmt.Errorf("no recorded response for %s", req.URL)
+}
+
+func (c *cassette) recordRoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	header := resp.Header.Clone()
+	for _, name := range volatileHeaders {
+		header.Del(name)
+	}
+	c.mu.Lock()
+	c.interactions = append(c.interactions, interaction{
+		Method:      req.Method,
+		URL:         req.URL.String(),
+		Status:      resp.StatusCode,
+		ContentType: header.Get("Content-Type"),
+		Header:      header,
+		Body:        string(body),
+	})
+	c.mu.Unlock()
+	return resp, nil
+}
+
+func (c *cassette) save() {
+	writeTestdata(c.t, c.path, c.interactions)
+}
+
+// assertGolden compares the jobs a scraper produced with
+// testdata/golden/<name>.json. With -update it rewrites the file instead.
+func assertGolden(t *testing.T, name string, got []jobs.Job) {
+	t.Helper()
+	path := filepath.Join("testdata", "golden", name+".json")
+	if *update || *record {
+		writeTestdata(t, path, got)
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading golden file: %v (run the test with -update to create it)", err)
+	}
+	if line, ok := firstDifference(string(want), string(marshalTestdata(t, got))); !ok {
+		t.Errorf("%s differs at line %d (run the test with -update if the change is expected)", path, line)
+	}
+}
+
+// firstDifference returns the first line at which two texts differ.
+func firstDifference(a, b string) (int, bool) {
+	al, bl := strings.Split(a, "\n"), strings.Split(b, "\n")
+	for i := 0; i < len(al) || i < len(bl); i++ {
+		if i >= len(al) || i >= len(bl) || al[i] != bl[i] {
+			return i + 1, false
+		}
+	}
+	return 0, true
+}
+
+// marshalTestdata formats v as indented JSON, leaving HTML in job
+// descriptions unescaped so diffs stay readable.
+func marshalTestdata(t *testing.T, v interface{}) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func writeTestdata(t *testing.T, path string, v interface{}) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, marshalTestdata(t, v), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
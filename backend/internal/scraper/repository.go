@@ -0,0 +1,275 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hiresense/backend/internal/config"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// RunRepository stores the history of scrape runs.
+type RunRepository struct {
+	runs *mongo.Collection
+}
+
+func NewRunRepository() *RunRepository {
+	return &RunRepository{
+		runs: config.GetCollection("scrape_runs"),
+	}
+}
+
+func (r *RunRepository) Save(ctx context.Context, run *ScrapeRun) error {
+	opts := options.Replace().SetUpsert(true)
+	_, err := r.runs.ReplaceOne(ctx, bson.M{"_id": run.ID}, run, opts)
+	return err
+}
+
+func (r *RunRepository) FindByID(ctx context.Context, id string) (*ScrapeRun, error) {
+	var run ScrapeRun
+	err := r.runs.FindOne(ctx, bson.M{"_id": id}).Decode(&run)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrRunNotFound
+		}
+		return nil, err
+	}
+	return &run, nil
+}
+
+// Abandon marks every running run other than exceptID as abandoned.
+func (r *RunRepository) Abandon(ctx context.Context, exceptID string, at time.Time) (int64, error) {
+	res, err := r.runs.UpdateMany(ctx,
+		bson.M{"_id": bson.M{"$ne": exceptID}, "status": RunRunning},
+		bson.M{"$set": bson.M{"status": RunAbandoned, "completedAt": at}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+// Recent returns the latest runs, newest first.
+func (r *RunRepository) Recent(ctx context.Context, limit int) ([]ScrapeRun, error) {
+	opts := options.Find().
+		SetSort(bson.M{"startedAt": -1}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.runs.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	runs := []ScrapeRun{}
+	if err := cursor.All(ctx, &runs); err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
+
+// ScheduleRepository stores per-source scrape schedules.
+type ScheduleRepository struct {
+	schedules *mongo.Collection
+}
+
+func NewScheduleRepository() *ScheduleRepository {
+	return &ScheduleRepository{
+		schedules: config.GetCollection("scrape_schedules"),
+	}
+}
+
+// Seed creates a default schedule for every source that has none yet.
+func (r *ScheduleRepository) Seed(ctx context.Context, sources []string, defaults Schedule) error {
+	for _, source := range sources {
+		_, err := r.schedules.UpdateOne(ctx, bson.M{"_id": source}, bson.M{
+			"$setOnInsert": bson.M{
+				"cron":          defaults.Cron,
+				"jitterSeconds": defaults.JitterSeconds,
+				"enabled":       defaults.Enabled,
+			},
+		}, options.Update().SetUpsert(true))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ScheduleRepository) All(ctx context.Context) ([]Schedule, error) {
+	cursor, err := r.schedules.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	schedules := []Schedule{}
+	if err := cursor.All(ctx, &schedules); err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+// Update changes the cron, jitter and enabled state, keeping the run history.
+func (r *ScheduleRepository) Update(ctx context.Context, sched *Schedule) (*Schedule, error) {
+	opts := options.FindOneAndUpdate().
+		SetUpsert(true).
+		SetReturnDocument(options.After)
+
+	var updated Schedule
+	err := r.schedules.FindOneAndUpdate(ctx, bson.M{"_id": sched.Source}, bson.M{
+		"$set": bson.M{
+			"cron":          sched.Cron,
+			"jitterSeconds": sched.JitterSeconds,
+			"enabled":       sched.Enabled,
+		},
+	}, opts).Decode(&updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (r *ScheduleRepository) MarkRun(ctx context.Context, sources []string, at time.Time) error {
+	_, err := r.schedules.UpdateMany(ctx,
+		bson.M{"_id": bson.M{"$in": sources}},
+		bson.M{"$set": bson.M{"lastRunAt": at}},
+	)
+	return err
+}
+
+// LockRepository implements expiring named locks, used to elect the one
+// server instance that runs the scheduler and to keep scrape runs on
+// different instances from overlapping.
+type LockRepository struct {
+	locks *mongo.Collection
+}
+
+func NewLockRepository() *LockRepository {
+	return &LockRepository{
+		locks: config.GetCollection("scheduler_locks"),
+	}
+}
+
+// TryAcquire takes or renews the lock for owner. It fails without error
+// when another owner holds an unexpired lock.
+func (r *LockRepository) TryAcquire(ctx context.Context, name, owner string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	filter := bson.M{
+		"_id": name,
+		"$or": []bson.M{
+			{"owner": owner},
+			{"expiresAt": bson.M{"$lt": now}},
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{"owner": owner, "expiresAt": now.Add(ttl)},
+	}
+
+	// When someone else holds the lock the filter misses and the upsert
+	// collides with the existing _id.
+	_, err := r.locks.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Holder returns the owner of an unexpired lock, or "" when it is free.
+func (r *LockRepository) Holder(ctx context.Context, name string) (string, error) {
+	var lock struct {
+		Owner string `bson:"owner"`
+	}
+	err := r.locks.FindOne(ctx, bson.M{"_id": name, "expiresAt": bson.M{"$gte": time.Now()}}).Decode(&lock)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return "", nil
+	}
+	return lock.Owner, err
+}
+
+func (r *LockRepository) Release(ctx context.Context, name, owner string) error {
+	_, err := r.locks.DeleteOne(ctx, bson.M{"_id": name, "owner": owner})
+	return err
+}
+
+// HealthRepository stores the health of each source.
+type HealthRepository struct {
+	health *mongo.Collection
+}
+
+func NewHealthRepository() *HealthRepository {
+	return &HealthRepository{
+		health: config.GetCollection("source_health"),
+	}
+}
+
+// Find returns the stored health of source, or nil if it has none.
+func (r *HealthRepository) Find(ctx context.Context, source string) (*SourceHealth, error) {
+	var h SourceHealth
+	err := r.health.FindOne(ctx, bson.M{"_id": source}).Decode(&h)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &h, nil
+}
+
+func (r *HealthRepository) All(ctx context.Context) ([]SourceHealth, error) {
+	cursor, err := r.health.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	health := []SourceHealth{}
+	if err := cursor.All(ctx, &health); err != nil {
+		return nil, err
+	}
+	return health, nil
+}
+
+func (r *HealthRepository) Save(ctx context.Context, h *SourceHealth) error {
+	opts := options.Replace().SetUpsert(true)
+	_, err := r.health.ReplaceOne(ctx, bson.M{"_id": h.Source}, h, opts)
+	return err
+}
+
+// ResponseRepository stores the last response of each fetched URL with its
+// ETag and Last-Modified validators, so conditional requests work after a
+// restart and on every instance.
+type ResponseRepository struct {
+	responses *mongo.Collection
+}
+
+func NewResponseRepository() *ResponseRepository {
+	return &ResponseRepository{
+		responses: config.GetCollection("http_cache"),
+	}
+}
+
+// Find returns the stored response for url, or nil if it has none.
+func (r *ResponseRepository) Find(ctx context.Context, url string) (*cachedResponse, error) {
+	var resp cachedResponse
+	err := r.responses.FindOne(ctx, bson.M{"_id": url}).Decode(&resp)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (r *ResponseRepository) Save(ctx context.Context, resp *cachedResponse) error {
+	opts := options.Replace().SetUpsert(true)
+	_, err := r.responses.ReplaceOne(ctx, bson.M{"_id": resp.URL}, resp, opts)
+	return err
+}
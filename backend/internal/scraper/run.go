@@ -0,0 +1,409 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/hiresense/backend/internal/skills"
+	"github.com/hiresense/backend/internal/sources"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrRunInProgress = errors.New("a scrape run is already in progress")
+	ErrRunNotFound   = errors.New("scrape run not found")
+	ErrRunFinished   = errors.New("scrape run already finished")
+)
+
+type RunStatus string
+
+const (
+	RunRunning   RunStatus = "running"
+	RunCompleted RunStatus = "completed"
+	RunCancelled RunStatus = "cancelled"
+	// RunAbandoned marks a run whose instance stopped before finishing it.
+	RunAbandoned RunStatus = "abandoned"
+)
+
+type SourceStatus string
+
+const (
+	SourcePending SourceStatus = "pending"
+	SourceRunning SourceStatus = "running"
+	SourceDone    SourceStatus = "done"
+)
+
+// RunTrigger records what started a run: an admin or the scheduler.
+type RunTrigger string
+
+const (
+	TriggerManual   RunTrigger = "manual"
+	TriggerSchedule RunTrigger = "schedule"
+)
+
+// ScrapeRun is a point-in-time view of a background scrape run.
+type ScrapeRun struct {
+	ID          string           `json:"id" bson:"_id"`
+	Status      RunStatus        `json:"status" bson:"status"`
+	Trigger     RunTrigger       `json:"trigger" bson:"trigger"`
+	Sources     []SourceProgress `json:"sources" bson:"sources"`
+	StartedAt   time.Time        `json:"startedAt" bson:"startedAt"`
+	CompletedAt *time.Time       `json:"completedAt,omitempty" bson:"completedAt,omitempty"`
+}
+
+type SourceProgress struct {
+	Source string        `json:"source" bson:"source"`
+	Status SourceStatus  `json:"status" bson:"status"`
+	Result *ScrapeResult `json:"result,omitempty" bson:"result,omitempty"`
+}
+
+// ErrorRate is the fraction of finished sources that reported errors.
+func (r *ScrapeRun) ErrorRate() float64 {
+	var finished, failed int
+	for _, src := range r.Sources {
+		if src.Result == nil {
+			continue
+		}
+		finished++
+		if len(src.Result.Errors) > 0 {
+			failed++
+		}
+	}
+	if finished == 0 {
+		return 0
+	}
+	return float64(failed) / float64(finished)
+}
+
+// maxRetainedRuns bounds how many finished runs stay available for polling.
+const maxRetainedRuns = 20
+
+type activeRun struct {
+	mu     sync.Mutex
+	run    ScrapeRun
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	// saveMu keeps an older snapshot from overwriting a newer one
+	saveMu sync.Mutex
+}
+
+func (r *activeRun) snapshot() ScrapeRun {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	run := r.run
+	run.Sources = make([]SourceProgress, len(r.run.Sources))
+	copy(run.Sources, r.run.Sources)
+	return run
+}
+
+func (r *activeRun) sourceStarted(i int) {
+	r.mu.Lock()
+	r.run.Sources[i].Status = SourceRunning
+	r.mu.Unlock()
+}
+
+func (r *activeRun) sourceFinished(i int, result ScrapeResult) {
+	r.mu.Lock()
+	r.run.Sources[i].Status = SourceDone
+	r.run.Sources[i].Result = &result
+	r.mu.Unlock()
+}
+
+func (r *activeRun) finish(status RunStatus) {
+	r.mu.Lock()
+	now := time.Now()
+	r.run.Status = status
+	r.run.CompletedAt = &now
+	r.mu.Unlock()
+	close(r.done)
+}
+
+const (
+	runLockKey = "scrape-run"
+	runLockTTL = 2 * time.Minute
+)
+
+// Start launches a background run of the named sources, or of every
+// scraper when none are given, and returns its ID immediately. Sources
+// disabled in the registry are left out. Only one run may be in progress at
+// a time across all instances; if one is, its ID is returned together with
+// ErrRunInProgress.
+func (m *ScraperManager) Start(trigger RunTrigger, names ...string) (string, error) {
+	if id := m.currentRunID(); id != "" {
+		return id, ErrRunInProgress
+	}
+
+	loadCtx, cancelLoad := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelLoad()
+	registry, err := m.loadRegistry(loadCtx)
+	if err != nil {
+		return "", err
+	}
+
+	runID := primitive.NewObjectID().Hex()
+	if holder, err := m.acquireRunLock(loadCtx, runID); err != nil {
+		return "", err
+	} else if holder != runID {
+		return holder, ErrRunInProgress
+	}
+	m.abandonStaleRuns(loadCtx, runID)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ar, scrapers, err := m.register(runID, trigger, names, registry, cancel)
+	if err != nil {
+		cancel()
+		m.releaseRunLock(runID)
+		if ar != nil {
+			return ar.run.ID, err
+		}
+		return "", err
+	}
+	// Saved outside m.mu so a slow database does not hold up polling
+	m.saveProgress(ar)
+
+	// Progress is saved as each source finishes, so instances that do not
+	// hold the run can report it
+	finished := func(i int, result ScrapeResult) {
+		ar.sourceFinished(i, result)
+		m.saveProgress(ar)
+	}
+
+	go func() {
+		defer cancel()
+		go m.holdRunLock(ctx, runID, cancel)
+		m.reloadSkillAliases(ctx)
+		m.runScrapers(ctx, scrapers, ar.sourceStarted, finished)
+
+		status := RunCompleted
+		if ctx.Err() != nil {
+			status = RunCancelled
+		}
+		m.mu.Lock()
+		m.current = nil
+		ar.finish(status)
+		m.mu.Unlock()
+
+		m.releaseRunLock(runID)
+		m.saveProgress(ar)
+	}()
+
+	return ar.run.ID, nil
+}
+
+// register makes runID the current run of the named sources that are
+// enabled in registry. It returns the current run with ErrRunInProgress
+// if another run started first.
+func (m *ScraperManager) register(runID string, trigger RunTrigger, names []string, registry map[string]sources.Source, cancel context.CancelFunc) (*activeRun, []Scraper, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.current != nil {
+		return m.current, nil, ErrRunInProgress
+	}
+
+	scrapers := m.scrapers
+	if len(names) > 0 {
+		scrapers = m.selectScrapers(names)
+	}
+	scrapers = configure(scrapers, registry)
+	if len(scrapers) == 0 {
+		return nil, nil, ErrNoEnabledSources
+	}
+
+	ar := &activeRun{
+		run: ScrapeRun{
+			ID:        runID,
+			Status:    RunRunning,
+			Trigger:   trigger,
+			Sources:   make([]SourceProgress, len(scrapers)),
+			StartedAt: time.Now(),
+		},
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	for i, s := range scrapers {
+		ar.run.Sources[i] = SourceProgress{Source: s.Name(), Status: SourcePending}
+	}
+
+	if m.runs == nil {
+		m.runs = make(map[string]*activeRun)
+	}
+	m.runs[ar.run.ID] = ar
+	m.runOrder = append(m.runOrder, ar.run.ID)
+	for len(m.runOrder) > maxRetainedRuns {
+		delete(m.runs, m.runOrder[0])
+		m.runOrder = m.runOrder[1:]
+	}
+	m.current = ar
+	return ar, scrapers, nil
+}
+
+func (m *ScraperManager) currentRunID() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.current == nil {
+		return ""
+	}
+	return m.current.run.ID
+}
+
+// acquireRunLock takes the run lock in MongoDB for runID and returns the
+// ID of the run holding it, which is runID when it was free.
+func (m *ScraperManager) acquireRunLock(ctx context.Context, runID string) (string, error) {
+	if m.locks == nil {
+		return runID, nil
+	}
+	ok, err := m.locks.TryAcquire(ctx, runLockKey, runID, runLockTTL)
+	if err != nil || ok {
+		return runID, err
+	}
+	return m.locks.Holder(ctx, runLockKey)
+}
+
+// holdRunLock renews the run lock until ctx is cancelled, so it only
+// expires when the instance running the scrape dies. If the lock cannot be
+// renewed the run is cancelled, since another instance may take it over.
+func (m *ScraperManager) holdRunLock(ctx context.Context, runID string, cancel context.CancelFunc) {
+	if m.locks == nil {
+		return
+	}
+	ticker := time.NewTicker(runLockTTL / 4)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			ok, err := m.locks.TryAcquire(ctx, runLockKey, runID, runLockTTL)
+			if ctx.Err() != nil {
+				return
+			}
+			switch {
+			case err != nil:
+				log.Printf("⚠️ Failed to renew the lock for scrape run %s, cancelling it: %v", runID, err)
+			case !ok:
+				log.Printf("⚠️ Scrape run %s lost its lock, cancelling it", runID)
+			default:
+				continue
+			}
+			cancel()
+			return
+		}
+	}
+}
+
+// abandonStaleRuns marks runs still recorded as running as abandoned. It
+// is called with the run lock held, so any such run belongs to an instance
+// that stopped before finishing it.
+func (m *ScraperManager) abandonStaleRuns(ctx context.Context, runID string) {
+	if m.runRepo == nil {
+		return
+	}
+	n, err := m.runRepo.Abandon(ctx, runID, time.Now())
+	if err != nil {
+		log.Printf("⚠️ Failed to mark stale scrape runs as abandoned: %v", err)
+	} else if n > 0 {
+		log.Printf("🧹 Marked %d stale scrape run(s) as abandoned", n)
+	}
+}
+
+func (m *ScraperManager) releaseRunLock(runID string) {
+	if m.locks == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := m.locks.Release(ctx, runLockKey, runID); err != nil {
+		log.Printf("⚠️ Failed to release the lock for scrape run %s: %v", runID, err)
+	}
+}
+
+func (m *ScraperManager) selectScrapers(names []string) []Scraper {
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[name] = true
+	}
+
+	var result []Scraper
+	for _, s := range m.scrapers {
+		if wanted[s.Name()] {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// reloadSkillAliases picks up aliases that admins added through another
+// instance, so every run normalizes skills the same way.
+func (m *ScraperManager) reloadSkillAliases(ctx context.Context) {
+	if m.aliases == nil {
+		return
+	}
+	if err := m.aliases.Load(ctx, skills.Default); err != nil {
+		log.Printf("⚠️ Failed to reload skill aliases: %v", err)
+	}
+}
+
+// saveRun records a run in the scrape_runs history.
+func (m *ScraperManager) saveRun(run ScrapeRun) {
+	if m.runRepo == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := m.runRepo.Save(ctx, &run); err != nil {
+		log.Printf("⚠️ Failed to save scrape run %s: %v", run.ID, err)
+	}
+}
+
+// saveProgress saves the current state of ar.
+func (m *ScraperManager) saveProgress(ar *activeRun) {
+	ar.saveMu.Lock()
+	defer ar.saveMu.Unlock()
+	m.saveRun(ar.snapshot())
+}
+
+// Run returns the current state of a run, falling back to the stored
+// history for runs that are no longer held in memory.
+func (m *ScraperManager) Run(ctx context.Context, id string) (ScrapeRun, error) {
+	m.mu.Lock()
+	ar, ok := m.runs[id]
+	m.mu.Unlock()
+
+	if ok {
+		return ar.snapshot(), nil
+	}
+	if m.runRepo == nil {
+		return ScrapeRun{}, ErrRunNotFound
+	}
+	run, err := m.runRepo.FindByID(ctx, id)
+	if err != nil {
+		return ScrapeRun{}, err
+	}
+	return *run, nil
+}
+
+// Cancel stops an in-progress run. Sources that already finished keep
+// their results; the others report a cancellation error.
+func (m *ScraperManager) Cancel(id string) error {
+	m.mu.Lock()
+	ar, ok := m.runs[id]
+	m.mu.Unlock()
+
+	if !ok {
+		return ErrRunNotFound
+	}
+	select {
+	case <-ar.done:
+		return ErrRunFinished
+	default:
+		ar.cancel()
+		return nil
+	}
+}
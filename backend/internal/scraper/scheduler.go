@@ -0,0 +1,237 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"os"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrScheduleNotFound = errors.New("schedule not found")
+	ErrInvalidSchedule  = errors.New("invalid schedule")
+)
+
+// Schedule controls when a source is scraped automatically. Cron is a
+// standard five-field expression evaluated in UTC.
+type Schedule struct {
+	Source        string     `json:"source" bson:"_id"`
+	Cron          string     `json:"cron" bson:"cron"`
+	JitterSeconds int        `json:"jitterSeconds" bson:"jitterSeconds"`
+	Enabled       bool       `json:"enabled" bson:"enabled"`
+	LastRunAt     *time.Time `json:"lastRunAt,omitempty" bson:"lastRunAt,omitempty"`
+	NextRunAt     *time.Time `json:"nextRunAt,omitempty" bson:"-"`
+}
+
+// ParseCron validates a standard five-field cron expression.
+func ParseCron(expr string) (cron.Schedule, error) {
+	return cron.ParseStandard(expr)
+}
+
+func (s *Schedule) validate() error {
+	if _, err := ParseCron(s.Cron); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSchedule, err)
+	}
+	if s.JitterSeconds < 0 {
+		return fmt.Errorf("%w: jitterSeconds must not be negative", ErrInvalidSchedule)
+	}
+	return nil
+}
+
+// next returns when the schedule is next due after its last run (or after
+// since, if it never ran), including its jitter offset.
+func (s *Schedule) next(since time.Time) (time.Time, error) {
+	sched, err := ParseCron(s.Cron)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	from := since
+	if s.LastRunAt != nil {
+		from = *s.LastRunAt
+	}
+	next := sched.Next(from.UTC())
+	return next.Add(s.jitter(next)), nil
+}
+
+// jitter spreads scheduled runs over [0, JitterSeconds). The offset is
+// derived from the source and fire time so every instance and every tick
+// agrees on it.
+func (s *Schedule) jitter(fireAt time.Time) time.Duration {
+	if s.JitterSeconds <= 0 {
+		return 0
+	}
+	h := fnv.New64a()
+	h.Write([]byte(s.Source))
+	h.Write([]byte(fireAt.UTC().Format(time.RFC3339)))
+	return time.Duration(h.Sum64()%uint64(s.JitterSeconds)) * time.Second
+}
+
+const (
+	schedulerTick = time.Minute
+	leaderLockTTL = 3 * schedulerTick
+	leaderLockKey = "scrape-scheduler"
+)
+
+// Scheduler starts scrape runs for sources whose schedule is due. Only the
+// instance holding the leader lock in MongoDB schedules anything, so
+// several server replicas never scrape at the same time.
+type Scheduler struct {
+	manager   *ScraperManager
+	schedules *ScheduleRepository
+	locks     *LockRepository
+	owner     string
+	startedAt time.Time
+	defaults  Schedule
+}
+
+func NewScheduler(manager *ScraperManager, defaultCron string, defaultJitter time.Duration) *Scheduler {
+	hostname, _ := os.Hostname()
+	return &Scheduler{
+		manager:   manager,
+		schedules: NewScheduleRepository(),
+		locks:     NewLockRepository(),
+		owner:     hostname + "/" + primitive.NewObjectID().Hex(),
+		startedAt: time.Now(),
+		defaults: Schedule{
+			Cron:          defaultCron,
+			JitterSeconds: int(defaultJitter / time.Second),
+			Enabled:       true,
+		},
+	}
+}
+
+// Run blocks until ctx is cancelled, checking for due sources every minute.
+func (s *Scheduler) Run(ctx context.Context) {
+	if _, err := ParseCron(s.defaults.Cron); err != nil {
+		log.Printf("❌ Scheduler disabled: invalid default cron %q: %v", s.defaults.Cron, err)
+		return
+	}
+	if err := s.schedules.Seed(ctx, s.manager.Sources(), s.defaults); err != nil {
+		log.Printf("⚠️ Failed to seed scrape schedules: %v", err)
+	}
+	log.Printf("⏰ Scrape scheduler started as %s", s.owner)
+
+	ticker := time.NewTicker(schedulerTick)
+	defer ticker.Stop()
+
+	for {
+		s.tick(ctx)
+		select {
+		case <-ctx.Done():
+			s.locks.Release(context.Background(), leaderLockKey, s.owner)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) tick(ctx context.Context) {
+	leader, err := s.locks.TryAcquire(ctx, leaderLockKey, s.owner, leaderLockTTL)
+	if err != nil {
+		log.Printf("⚠️ Scheduler lock: %v", err)
+		return
+	}
+	if !leader {
+		return
+	}
+
+	schedules, err := s.schedules.All(ctx)
+	if err != nil {
+		log.Printf("⚠️ Loading scrape schedules: %v", err)
+		return
+	}
+
+	now := time.Now()
+	due := dueSources(schedules, s.startedAt, now)
+	if len(due) == 0 {
+		return
+	}
+
+	runID, err := s.manager.Start(TriggerSchedule, due...)
+	if errors.Is(err, ErrRunInProgress) {
+		// Try again on the next tick
+		return
+	}
+	if errors.Is(err, ErrNoEnabledSources) {
+		// Only disabled sources were due; wait for their next slot
+		if err := s.schedules.MarkRun(ctx, due, now); err != nil {
+			log.Printf("⚠️ Failed to record scheduled run: %v", err)
+		}
+		return
+	}
+	if err != nil {
+		log.Printf("⚠️ Scheduled scrape failed to start: %v", err)
+		return
+	}
+
+	log.Printf("⏰ Scheduled scrape %s started for %v", runID, due)
+	if err := s.schedules.MarkRun(ctx, due, now); err != nil {
+		log.Printf("⚠️ Failed to record scheduled run: %v", err)
+	}
+}
+
+// dueSources returns the enabled sources whose next run time has passed.
+func dueSources(schedules []Schedule, since, now time.Time) []string {
+	var due []string
+	for i := range schedules {
+		sched := &schedules[i]
+		if !sched.Enabled {
+			continue
+		}
+		next, err := sched.next(since)
+		if err != nil {
+			log.Printf("⚠️ Invalid cron for %s: %v", sched.Source, err)
+			continue
+		}
+		if !next.After(now) {
+			due = append(due, sched.Source)
+		}
+	}
+	return due
+}
+
+// Schedules returns every schedule with its next run time filled in.
+func (s *Scheduler) Schedules(ctx context.Context) ([]Schedule, error) {
+	schedules, err := s.schedules.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range schedules {
+		if next, err := schedules[i].next(time.Now()); err == nil && schedules[i].Enabled {
+			schedules[i].NextRunAt = &next
+		}
+	}
+	return schedules, nil
+}
+
+// UpdateSchedule changes a registered source's schedule.
+func (s *Scheduler) UpdateSchedule(ctx context.Context, sched Schedule) (*Schedule, error) {
+	known := false
+	for _, name := range s.manager.Sources() {
+		if name == sched.Source {
+			known = true
+		}
+	}
+	if !known {
+		return nil, ErrScheduleNotFound
+	}
+	if err := sched.validate(); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.schedules.Update(ctx, &sched)
+	if err != nil {
+		return nil, err
+	}
+	if next, err := updated.next(time.Now()); err == nil && updated.Enabled {
+		updated.NextRunAt = &next
+	}
+	return updated, nil
+}
@@ -0,0 +1,48 @@
+package scraper
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDueSources(t *testing.T) {
+	startedAt := time.Date(2024, 10, 14, 5, 0, 0, 0, time.UTC)
+	lastRun := time.Date(2024, 10, 13, 6, 0, 0, 0, time.UTC)
+
+	schedules := []Schedule{
+		{Source: "daily", Cron: "0 6 * * *", Enabled: true},
+		{Source: "disabled", Cron: "0 6 * * *", Enabled: false},
+		{Source: "ranToday", Cron: "0 6 * * *", Enabled: true, LastRunAt: ptrTime(startedAt.Add(90 * time.Minute))},
+		{Source: "catchUp", Cron: "0 6 * * *", Enabled: true, LastRunAt: &lastRun},
+		{Source: "laterToday", Cron: "0 9 * * *", Enabled: true},
+		{Source: "invalid", Cron: "not a cron", Enabled: true},
+	}
+
+	now := time.Date(2024, 10, 14, 6, 30, 0, 0, time.UTC)
+	got := dueSources(schedules, startedAt, now)
+	if want := []string{"daily", "catchUp"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("dueSources = %v, want %v", got, want)
+	}
+}
+
+func TestScheduleJitter(t *testing.T) {
+	s := Schedule{Source: "RemoteOK", Cron: "0 6 * * *", JitterSeconds: 600, Enabled: true}
+	since := time.Date(2024, 10, 14, 0, 0, 0, 0, time.UTC)
+
+	first, err := s.next(since)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fireAt := time.Date(2024, 10, 14, 6, 0, 0, 0, time.UTC)
+	if first.Before(fireAt) || !first.Before(fireAt.Add(10*time.Minute)) {
+		t.Errorf("next = %v, want within 10m after %v", first, fireAt)
+	}
+
+	// Every instance and tick must agree on the jittered time
+	if again, _ := s.next(since); !again.Equal(first) {
+		t.Errorf("jitter is not stable: %v vs %v", first, again)
+	}
+}
+
+func ptrTime(t time.Time) *time.Time { return &t }
@@ -2,14 +2,16 @@ package scraper
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log"
-	"net/http"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/hiresense/backend/internal/config"
 	"github.com/hiresense/backend/internal/jobs"
+	"github.com/hiresense/backend/internal/skills"
+	"github.com/hiresense/backend/internal/sources"
 )
 
 type Scraper interface {
@@ -18,101 +20,315 @@ type Scraper interface {
 }
 
 type ScraperManager struct {
-	scrapers []Scraper
-	jobsRepo *jobs.Repository
+	scrapers    []Scraper
+	jobsRepo    jobs.Store
+	runRepo     *RunRepository
+	aliases     *skills.Repository
+	health      *HealthTracker
+	registry    *sources.Repository
+	locks       *LockRepository
+	concurrency int
+	timeout     time.Duration
+
+	// Shared by every scraper's requests
+	retry   retryPolicy
+	limiter *hostLimiter
+	cache   *responseCache
+
+	// Jobs missing from this many successful runs, or unseen for this
+	// long, are expired
+	expiryMissedRuns int
+	expiryMaxAge     time.Duration
+
+	// Background runs
+	mu       sync.Mutex
+	current  *activeRun
+	runs     map[string]*activeRun
+	runOrder []string
+}
+
+// fetcher is a scraper that fetches through httpSource.
+type fetcher interface {
+	setFetchPolicy(retry *retryPolicy, limiter *hostLimiter, cache *responseCache)
 }
 
 func NewScraperManager() *ScraperManager {
-	return &ScraperManager{
-		scrapers: []Scraper{
-			NewRemoteOKScraper(),
-			NewRemotiveScraper(),
-		},
-		jobsRepo: jobs.NewRepository(),
+	scrapers := []Scraper{
+		NewRemoteOKScraper(),
+		NewRemotiveScraper(),
+		NewWeWorkRemotelyScraper(),
+		NewHackerNewsScraper(config.AppConfig.HNThreadIDs),
+
+		// Company boards and career pages are configured in the source
+		// registry, seeded from the config
+		NewLeverScraper(config.AppConfig.LeverCompanies),
+		NewGreenhouseScraper(config.AppConfig.GreenhouseBoards),
+		NewJobPostingScraper(config.AppConfig.JobPostingURLs),
+	}
+
+	// Declarative JSON API scrapers
+	scrapers = append(scrapers, loadDefinitionScrapers(config.AppConfig.ScraperDefinitionsDir, scrapers)...)
+
+	m := &ScraperManager{
+		scrapers:    scrapers,
+		jobsRepo:    jobs.NewRepository(),
+		runRepo:     NewRunRepository(),
+		aliases:     skills.NewRepository(),
+		registry:    sources.NewRepository(),
+		locks:       NewLockRepository(),
+		health:      NewHealthTracker(NewHealthRepository(), config.AppConfig.CircuitFailureThreshold, config.AppConfig.CircuitCooldown),
+		concurrency: config.AppConfig.ScrapeConcurrency,
+		timeout:     config.AppConfig.ScrapeTimeout,
+
+		expiryMissedRuns: config.AppConfig.JobExpiryMissedRuns,
+		expiryMaxAge:     config.AppConfig.JobExpiryMaxAge,
+
+		retry:   defaultRetry,
+		limiter: newHostLimiter(config.AppConfig.ScrapeHostInterval),
+		cache:   newResponseCache(NewResponseRepository(), responseCacheBytes),
+	}
+	m.retry.retries = config.AppConfig.ScrapeRetries
+	for _, s := range scrapers {
+		if f, ok := s.(fetcher); ok {
+			f.setFetchPolicy(&m.retry, m.limiter, m.cache)
+		}
 	}
+	return m
+}
+
+// Sources returns the names of the registered scrapers.
+func (m *ScraperManager) Sources() []string {
+	names := make([]string, len(m.scrapers))
+	for i, s := range m.scrapers {
+		names[i] = s.Name()
+	}
+	return names
 }
 
 type ScrapeResult struct {
-	Source      string    `json:"source"`
-	JobsScraped int       `json:"jobsScraped"`
-	JobsAdded   int       `json:"jobsAdded"`
-	JobsUpdated int       `json:"jobsUpdated"`
-	Errors      []string  `json:"errors"`
-	StartedAt   time.Time `json:"startedAt"`
-	CompletedAt time.Time `json:"completedAt"`
+	Source        string    `json:"source" bson:"source"`
+	JobsScraped   int       `json:"jobsScraped" bson:"jobsScraped"`
+	JobsAdded     int       `json:"jobsAdded" bson:"jobsAdded"`
+	JobsUpdated   int       `json:"jobsUpdated" bson:"jobsUpdated"`
+	JobsUnchanged int       `json:"jobsUnchanged" bson:"jobsUnchanged"`
+	JobsFailed    int       `json:"jobsFailed" bson:"jobsFailed"`
+	JobsExpired   int       `json:"jobsExpired" bson:"jobsExpired"`
+	Errors        []string  `json:"errors" bson:"errors"`
+	Warnings      []string  `json:"warnings,omitempty" bson:"warnings,omitempty"`
+	Skipped       bool      `json:"skipped,omitempty" bson:"skipped,omitempty"`
+	StartedAt     time.Time `json:"startedAt" bson:"startedAt"`
+	CompletedAt   time.Time `json:"completedAt" bson:"completedAt"`
 }
 
-func (m *ScraperManager) RunAll(ctx context.Context) []ScrapeResult {
-	results := make([]ScrapeResult, 0, len(m.scrapers))
+// maxItemErrors bounds how many per-job storage errors a result lists.
+const maxItemErrors = 20
+
+// runScrapers runs the given scrapers concurrently, at most m.concurrency
+// at a time, each with its own deadline. Results are returned in the
+// scrapers' order. The optional callbacks report when each scraper,
+// identified by its index, starts and finishes.
+func (m *ScraperManager) runScrapers(ctx context.Context, scrapers []Scraper, onStart func(int), onDone func(int, ScrapeResult)) []ScrapeResult {
+	results := make([]ScrapeResult, len(scrapers))
+	startedAt := time.Now()
 
-	for _, scraper := range m.scrapers {
-		result := m.runScraper(ctx, scraper)
-		results = append(results, result)
+	concurrency := m.concurrency
+	if concurrency < 1 {
+		concurrency = 1
 	}
+	sem := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	for i, scraper := range scrapers {
+		wg.Add(1)
+		go func(i int, scraper Scraper) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+				if onStart != nil {
+					onStart(i)
+				}
+				results[i] = m.runScraper(ctx, scraper)
+				m.recordHealth(ctx, results[i])
+			case <-ctx.Done():
+				results[i] = ScrapeResult{
+					Source:      scraper.Name(),
+					Errors:      []string{ctx.Err().Error()},
+					StartedAt:   time.Now(),
+					CompletedAt: time.Now(),
+				}
+			}
+
+			if onDone != nil {
+				onDone(i, results[i])
+			}
+		}(i, scraper)
+	}
+	wg.Wait()
+
+	m.clusterDuplicates(startedAt)
 
 	return results
 }
 
-func (m *ScraperManager) runScraper(ctx context.Context, scraper Scraper) ScrapeResult {
-	result := ScrapeResult{
+// clusterDuplicates merges cross-source duplicates among the jobs a run
+// stored or expired. It runs even when the run was cancelled, since the
+// sources that finished have already written their jobs.
+func (m *ScraperManager) clusterDuplicates(since time.Time) {
+	if m.jobsRepo == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	changed, err := m.jobsRepo.ClusterDuplicates(ctx, since)
+	if err != nil {
+		log.Printf("⚠️ Duplicate clustering failed: %v", err)
+		return
+	}
+	if changed > 0 {
+		log.Printf("🔗 Updated duplicate clusters for %d jobs", changed)
+	}
+}
+
+// recordHealth updates the source's health with a run's result. Skipped
+// sources and runs cut short by cancellation say nothing about the source.
+func (m *ScraperManager) recordHealth(ctx context.Context, result ScrapeResult) {
+	if m.health == nil || result.Skipped || ctx.Err() != nil {
+		return
+	}
+	m.health.Record(ctx, result)
+}
+
+// Health returns the health of every registered source.
+func (m *ScraperManager) Health(ctx context.Context) ([]SourceHealth, error) {
+	if m.health == nil {
+		return []SourceHealth{}, nil
+	}
+	return m.health.All(ctx, m.Sources())
+}
+
+func (m *ScraperManager) runScraper(ctx context.Context, scraper Scraper) (result ScrapeResult) {
+	result = ScrapeResult{
 		Source:    scraper.Name(),
 		StartedAt: time.Now(),
 		Errors:    []string{},
 	}
 
+	// Sources whose circuit is open are skipped until it half-opens
+	if m.health != nil {
+		if err := m.health.Allow(ctx, scraper.Name(), result.StartedAt); err != nil {
+			log.Printf("🔌 %s: skipped, %v", scraper.Name(), err)
+			result.Errors = append(result.Errors, err.Error())
+			result.Skipped = true
+			result.CompletedAt = time.Now()
+			return result
+		}
+	}
+
+	if m.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.timeout)
+		defer cancel()
+	}
+
+	// A panicking scraper must not take the other sources down with it
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("❌ %s: panic: %v", scraper.Name(), r)
+			result.Errors = append(result.Errors, fmt.Sprintf("panic: %v", r))
+			result.CompletedAt = time.Now()
+		}
+	}()
+
+	// Scrapers that read several feeds may return partial results
+	// alongside an error, so only bail out when nothing came back.
 	jobsList, err := scraper.Scrape(ctx)
 	if err != nil {
 		result.Errors = append(result.Errors, err.Error())
-		result.CompletedAt = time.Now()
-		return result
+		if len(jobsList) == 0 {
+			result.CompletedAt = time.Now()
+			return result
+		}
 	}
 
 	result.JobsScraped = len(jobsList)
+	normalizeJobs(jobsList)
 
 	// Store jobs
-	added, updated, err := m.jobsRepo.BulkUpsert(ctx, jobsList)
-	if err != nil {
-		result.Errors = append(result.Errors, err.Error())
+	stored, storeErr := m.jobsRepo.BulkUpsert(ctx, jobsList)
+	if stored != nil {
+		result.JobsAdded = stored.Added
+		result.JobsUpdated = stored.Updated
+		result.JobsUnchanged = stored.Unchanged
+		result.JobsFailed = stored.Failed
+		for i, itemErr := range stored.Errors {
+			if i == maxItemErrors {
+				result.Errors = append(result.Errors, fmt.Sprintf("... and %d more failed jobs", len(stored.Errors)-i))
+				break
+			}
+			result.Errors = append(result.Errors, itemErr.Error())
+		}
+	}
+	if storeErr != nil {
+		result.Errors = append(result.Errors, storeErr.Error())
+	}
+
+	// A run that looks like the source changed its format is kept, but
+	// not trusted to say which jobs are gone
+	if m.health != nil && err == nil && storeErr == nil {
+		result.Warnings = m.health.YieldWarnings(ctx, scraper.Name(), result.JobsScraped)
+		for _, warning := range result.Warnings {
+			log.Printf("⚠️ %s: %s, possible format change; not expiring jobs", scraper.Name(), warning)
+		}
 	}
 
-	result.JobsAdded = added
-	result.JobsUpdated = updated
+	// A failed or partial scrape says nothing about which jobs are gone
+	if err == nil && storeErr == nil && len(result.Warnings) == 0 {
+		expired, err := m.expireJobs(ctx, scraper.Name(), result.StartedAt)
+		if err != nil {
+			result.Errors = append(result.Errors, err.Error())
+		}
+		result.JobsExpired = expired
+	}
 	result.CompletedAt = time.Now()
 
-	log.Printf("✅ %s: scraped %d, added %d, updated %d",
-		scraper.Name(), result.JobsScraped, result.JobsAdded, result.JobsUpdated)
+	log.Printf("✅ %s: scraped %d, added %d, updated %d, unchanged %d, failed %d, expired %d",
+		scraper.Name(), result.JobsScraped, result.JobsAdded, result.JobsUpdated,
+		result.JobsUnchanged, result.JobsFailed, result.JobsExpired)
 
 	return result
 }
 
+// expireJobs deactivates jobs from source that the run started at
+// startedAt did not see, and jobs whose validThrough date has passed.
+func (m *ScraperManager) expireJobs(ctx context.Context, source string, startedAt time.Time) (int, error) {
+	missing, err := m.jobsRepo.ExpireMissing(ctx, source, startedAt, m.expiryMissedRuns, m.expiryMaxAge)
+	if err != nil {
+		return 0, err
+	}
+	pastValid, err := m.jobsRepo.ExpirePastValidThrough(ctx, source)
+	if err != nil {
+		return int(missing), err
+	}
+	return int(missing + pastValid), nil
+}
+
 // RemoteOK Scraper
 type RemoteOKScraper struct {
-	client *http.Client
+	httpSource
 }
 
 func NewRemoteOKScraper() *RemoteOKScraper {
-	return &RemoteOKScraper{
-		client: &http.Client{Timeout: 30 * time.Second},
-	}
+	return &RemoteOKScraper{httpSource: newHTTPSource("https://remoteok.com")}
 }
 
 func (s *RemoteOKScraper) Name() string { return "RemoteOK" }
 
 func (s *RemoteOKScraper) Scrape(ctx context.Context) ([]jobs.Job, error) {
-	req, err := http.NewRequestWithContext(ctx, "GET", "https://remoteok.com/api", nil)
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("User-Agent", "HireSense Job Aggregator")
-
-	resp, err := s.client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var rawJobs []map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&rawJobs); err != nil {
+	if err := s.getJSON(ctx, "/api", nil, &rawJobs); err != nil {
 		return nil, err
 	}
 
@@ -132,16 +348,26 @@ func (s *RemoteOKScraper) Scrape(ctx context.Context) ([]jobs.Job, error) {
 			URL:         getString(raw, "url"),
 			Source:      "RemoteOK",
 			SourceID:    fmt.Sprintf("%v", raw["id"]),
-			Skills:      parseSkills(getString(raw, "tags")),
+			Skills:      stringList(raw["tags"]),
 			IsActive:    true,
 		}
 
-		if dateStr := getString(raw, "date"); dateStr != "" {
-			if t, err := time.Parse("2006-01-02T15:04:05", dateStr); err == nil {
-				job.PostedAt = t
+		// salary_min/salary_max are annual USD amounts
+		low, _ := raw["salary_min"].(float64)
+		high, _ := raw["salary_max"].(float64)
+		if info := jobs.NewSalaryInfo(low, high, "USD", jobs.PeriodYear); info != nil {
+			job.SalaryInfo = info
+			if job.Salary == "" {
+				job.Salary = info.String()
 			}
 		}
 
+		if t, ok := parsePostedDate(raw["date"]); ok {
+			job.PostedAt = t
+		} else if t, ok := parsePostedDate(raw["epoch"]); ok {
+			job.PostedAt = t
+		}
+
 		if job.Title != "" && job.Company != "" {
 			result = append(result, job)
 		}
@@ -152,56 +378,41 @@ func (s *RemoteOKScraper) Scrape(ctx context.Context) ([]jobs.Job, error) {
 
 // Remotive Scraper
 type RemotiveScraper struct {
-	client *http.Client
+	httpSource
 }
 
 func NewRemotiveScraper() *RemotiveScraper {
-	return &RemotiveScraper{
-		client: &http.Client{Timeout: 30 * time.Second},
-	}
+	return &RemotiveScraper{httpSource: newHTTPSource("https://remotive.com")}
 }
 
 func (s *RemotiveScraper) Name() string { return "Remotive" }
 
 func (s *RemotiveScraper) Scrape(ctx context.Context) ([]jobs.Job, error) {
-	req, err := http.NewRequestWithContext(ctx, "GET", "https://remotive.com/api/remote-jobs", nil)
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("User-Agent", "HireSense Job Aggregator")
-
-	resp, err := s.client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
 	var response struct {
 		Jobs []map[string]interface{} `json:"jobs"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err := s.getJSON(ctx, "/api/remote-jobs", nil, &response); err != nil {
 		return nil, err
 	}
 
 	result := make([]jobs.Job, 0, len(response.Jobs))
 	for _, raw := range response.Jobs {
 		job := jobs.Job{
-			Title:       getString(raw, "title"),
-			Company:     getString(raw, "company_name"),
-			Description: getString(raw, "description"),
-			Salary:      getString(raw, "salary"),
-			Location:    getString(raw, "candidate_required_location", "Worldwide"),
-			URL:         getString(raw, "url"),
-			Source:      "Remotive",
-			SourceID:    fmt.Sprintf("%v", raw["id"]),
-			Skills:      parseTagsArray(raw["tags"]),
-			IsActive:    true,
+			Title:          getString(raw, "title"),
+			Company:        getString(raw, "company_name"),
+			Description:    getString(raw, "description"),
+			Salary:         getString(raw, "salary"),
+			Location:       getString(raw, "candidate_required_location", "Worldwide"),
+			URL:            getString(raw, "url"),
+			Source:         "Remotive",
+			SourceID:       fmt.Sprintf("%v", raw["id"]),
+			Skills:         parseTagsArray(raw["tags"]),
+			EmploymentType: getString(raw, "job_type"),
+			IsActive:       true,
 		}
 
-		if dateStr := getString(raw, "publication_date"); dateStr != "" {
-			if t, err := time.Parse("2006-01-02T15:04:05", dateStr); err == nil {
-				job.PostedAt = t
-			}
+		if t, ok := parsePostedDate(raw["publication_date"]); ok {
+			job.PostedAt = t
 		}
 
 		if job.Title != "" && job.Company != "" {
@@ -0,0 +1,150 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hiresense/backend/internal/jobs"
+)
+
+// fakeScraper returns err after delay, or when its context ends first.
+type fakeScraper struct {
+	name  string
+	delay time.Duration
+	err   error
+	panic bool
+}
+
+func (s *fakeScraper) Name() string { return s.name }
+
+func (s *fakeScraper) Scrape(ctx context.Context) ([]jobs.Job, error) {
+	if s.panic {
+		panic("boom")
+	}
+	select {
+	case <-time.After(s.delay):
+		return nil, s.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func TestRunScrapersConcurrentWithTimeouts(t *testing.T) {
+	m := &ScraperManager{
+		scrapers: []Scraper{
+			&fakeScraper{name: "slow", delay: time.Hour},
+			&fakeScraper{name: "broken", err: errors.New("bad gateway")},
+			&fakeScraper{name: "panics", panic: true},
+			&fakeScraper{name: "fast", delay: 10 * time.Millisecond, err: errors.New("done")},
+		},
+		concurrency: 2,
+		timeout:     100 * time.Millisecond,
+	}
+
+	var started, finished atomic.Int32
+	start := time.Now()
+	results := m.runScrapers(context.Background(), m.scrapers,
+		func(int) { started.Add(1) },
+		func(int, ScrapeResult) { finished.Add(1) })
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("runScrapers took %v; the slow source should have timed out", elapsed)
+	}
+	if started.Load() != 4 || finished.Load() != 4 {
+		t.Errorf("started %d and finished %d sources, want 4 each", started.Load(), finished.Load())
+	}
+
+	want := []struct{ source, err string }{
+		{"slow", "deadline exceeded"},
+		{"broken", "bad gateway"},
+		{"panics", "panic: boom"},
+		{"fast", "done"},
+	}
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d", len(results), len(want))
+	}
+	for i, w := range want {
+		r := results[i]
+		if r.Source != w.source {
+			t.Errorf("results[%d].Source = %q, want %q", i, r.Source, w.source)
+		}
+		if len(r.Errors) != 1 || !strings.Contains(r.Errors[0], w.err) {
+			t.Errorf("%s: errors = %v, want %q", r.Source, r.Errors, w.err)
+		}
+		if r.CompletedAt.IsZero() {
+			t.Errorf("%s: CompletedAt not set", r.Source)
+		}
+	}
+}
+
+func waitForRun(t *testing.T, m *ScraperManager, id string) ScrapeRun {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		run, err := m.Run(context.Background(), id)
+		if err != nil {
+			t.Fatalf("Run(%s): %v", id, err)
+		}
+		if run.Status != RunRunning {
+			return run
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("run %s did not finish", id)
+	return ScrapeRun{}
+}
+
+func TestBackgroundRunLifecycle(t *testing.T) {
+	m := &ScraperManager{
+		scrapers: []Scraper{
+			&fakeScraper{name: "quick", err: errors.New("done")},
+			&fakeScraper{name: "slow", delay: time.Hour},
+		},
+		concurrency: 2,
+		timeout:     time.Hour,
+	}
+
+	id, err := m.Start(TriggerManual)
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if again, err := m.Start(TriggerManual); !errors.Is(err, ErrRunInProgress) || again != id {
+		t.Fatalf("second Start = %q, %v; want %q, ErrRunInProgress", again, err, id)
+	}
+
+	run, err := m.Run(context.Background(), id)
+	if err != nil || run.Status != RunRunning || len(run.Sources) != 2 {
+		t.Fatalf("Run = %+v, %v", run, err)
+	}
+
+	if err := m.Cancel(id); err != nil {
+		t.Fatalf("Cancel: %v", err)
+	}
+	run = waitForRun(t, m, id)
+	if run.Status != RunCancelled || run.CompletedAt == nil {
+		t.Errorf("status = %q, completedAt = %v", run.Status, run.CompletedAt)
+	}
+	for _, src := range run.Sources {
+		if src.Status != SourceDone || src.Result == nil {
+			t.Errorf("%s: status = %q, result = %v", src.Source, src.Status, src.Result)
+		}
+	}
+
+	if err := m.Cancel(id); !errors.Is(err, ErrRunFinished) {
+		t.Errorf("Cancel after finish = %v, want ErrRunFinished", err)
+	}
+	if _, err := m.Run(context.Background(), "unknown"); !errors.Is(err, ErrRunNotFound) {
+		t.Errorf("Run(unknown) = %v, want ErrRunNotFound", err)
+	}
+
+	// A new run can start once the previous one is over
+	next, err := m.Start(TriggerManual)
+	if err != nil {
+		t.Fatalf("Start after finish: %v", err)
+	}
+	m.Cancel(next)
+	waitForRun(t, m, next)
+}
@@ -0,0 +1,445 @@
+package scraper
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hiresense/backend/internal/jobs"
+)
+
+// Import and export formats
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+)
+
+const (
+	maxImportRows     = 10000
+	maxImportLineSize = 4 << 20
+)
+
+var ErrInvalidImport = errors.New("invalid import")
+
+// RowError is a problem with one row of an import. Rows are numbered as
+// lines of the file, so a CSV header is row 1.
+type RowError struct {
+	Row   int    `json:"row"`
+	Error string `json:"error"`
+}
+
+type ImportResult struct {
+	Rows      int        `json:"rows"`
+	Added     int        `json:"added"`
+	Updated   int        `json:"updated"`
+	Unchanged int        `json:"unchanged"`
+	Failed    int        `json:"failed"`
+	Errors    []RowError `json:"errors"`
+}
+
+// csvColumn maps a CSV column onto a job field. Columns without set are
+// exported for reference and ignored on import.
+type csvColumn struct {
+	name string
+	get  func(j *jobs.Job) string
+	set  func(j *jobs.Job, v string) error
+}
+
+var csvColumns = []csvColumn{
+	{name: "id", get: func(j *jobs.Job) string {
+		if j.ID.IsZero() {
+			return ""
+		}
+		return j.ID.Hex()
+	}},
+	{name: "source", get: func(j *jobs.Job) string { return j.Source }},
+	{name: "sourceId", get: func(j *jobs.Job) string { return j.SourceID }, set: func(j *jobs.Job, v string) error {
+		j.SourceID = v
+		return nil
+	}},
+	{name: "title", get: func(j *jobs.Job) string { return j.Title }, set: func(j *jobs.Job, v string) error {
+		j.Title = v
+		return nil
+	}},
+	{name: "company", get: func(j *jobs.Job) string { return j.Company }, set: func(j *jobs.Job, v string) error {
+		j.Company = v
+		return nil
+	}},
+	{name: "location", get: func(j *jobs.Job) string { return j.Location }, set: func(j *jobs.Job, v string) error {
+		j.Location = v
+		return nil
+	}},
+	{name: "salary", get: func(j *jobs.Job) string { return j.Salary }, set: func(j *jobs.Job, v string) error {
+		j.Salary = v
+		return nil
+	}},
+	{name: "skills", get: func(j *jobs.Job) string { return strings.Join(j.Skills, "|") }, set: func(j *jobs.Job, v string) error {
+		j.Skills = parseSkills(strings.ReplaceAll(v, "|", ","))
+		return nil
+	}},
+	{name: "seniority", get: func(j *jobs.Job) string { return j.Seniority }, set: func(j *jobs.Job, v string) error {
+		j.Seniority = v
+		return nil
+	}},
+	{name: "employmentType", get: func(j *jobs.Job) string { return j.EmploymentType }, set: func(j *jobs.Job, v string) error {
+		j.EmploymentType = v
+		return nil
+	}},
+	{name: "url", get: func(j *jobs.Job) string { return j.URL }, set: func(j *jobs.Job, v string) error {
+		j.URL = v
+		return nil
+	}},
+	{name: "postedAt", get: func(j *jobs.Job) string {
+		// An unknown date is stored as the date the job was first seen
+		if j.DateUnknown {
+			return ""
+		}
+		return formatTime(&j.PostedAt)
+	}, set: func(j *jobs.Job, v string) error {
+		if v == "" {
+			return nil
+		}
+		t, ok := parsePostedDate(v)
+		if !ok {
+			return fmt.Errorf("postedAt: unrecognised date %q", v)
+		}
+		j.PostedAt = t
+		return nil
+	}},
+	{name: "validThrough", get: func(j *jobs.Job) string { return formatTime(j.ValidThrough) }, set: func(j *jobs.Job, v string) error {
+		if v == "" {
+			return nil
+		}
+		t, ok := parseDate(v)
+		if !ok {
+			return fmt.Errorf("validThrough: unrecognised date %q", v)
+		}
+		j.ValidThrough = &t
+		return nil
+	}},
+	{name: "isActive", get: func(j *jobs.Job) string { return strconv.FormatBool(j.IsActive) }},
+	{name: "description", get: func(j *jobs.Job) string { return j.Description }, set: func(j *jobs.Job, v string) error {
+		j.Description = v
+		return nil
+	}},
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// columnKey matches header names loosely, so "Source ID" and "source_id"
+// both name the sourceId column.
+func columnKey(name string) string {
+	return strings.NewReplacer(" ", "", "_", "", "-", "").Replace(strings.ToLower(strings.TrimSpace(name)))
+}
+
+// importRow is a decoded row waiting to be stored.
+type importRow struct {
+	row int
+	job jobs.Job
+}
+
+// decodeImport reads the jobs in r. Rows that do not make a valid job are
+// returned as RowErrors; a file that cannot be read at all is an error.
+func decodeImport(r io.Reader, format string) ([]importRow, []RowError, error) {
+	var (
+		rows    []importRow
+		rowErrs []RowError
+		err     error
+	)
+	switch format {
+	case FormatCSV:
+		rows, rowErrs, err = decodeCSV(r)
+	case FormatNDJSON:
+		rows, rowErrs, err = decodeNDJSON(r)
+	default:
+		return nil, nil, fmt.Errorf("%w: format must be csv or ndjson", ErrInvalidImport)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Validate after decoding so both formats report the same problems
+	valid := rows[:0]
+	firstRow := make(map[string]int, len(rows))
+	for _, row := range rows {
+		if err := prepareImport(&row.job); err != nil {
+			rowErrs = append(rowErrs, RowError{Row: row.row, Error: err.Error()})
+			continue
+		}
+		if first, ok := firstRow[row.job.SourceID]; ok {
+			rowErrs = append(rowErrs, RowError{Row: row.row, Error: fmt.Sprintf("duplicate sourceId %q, first seen in row %d", row.job.SourceID, first)})
+			continue
+		}
+		firstRow[row.job.SourceID] = row.row
+		valid = append(valid, row)
+	}
+	sort.Slice(rowErrs, func(i, j int) bool { return rowErrs[i].Row < rowErrs[j].Row })
+	return valid, rowErrs, nil
+}
+
+func decodeCSV(r io.Reader) ([]importRow, []RowError, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: reading header: %w", ErrInvalidImport, err)
+	}
+	columns := make([]*csvColumn, len(header))
+	for i, name := range header {
+		for j := range csvColumns {
+			if columnKey(csvColumns[j].name) == columnKey(name) {
+				columns[i] = &csvColumns[j]
+			}
+		}
+		if columns[i] == nil {
+			return nil, nil, fmt.Errorf("%w: unknown column %q", ErrInvalidImport, name)
+		}
+	}
+
+	var (
+		rows    []importRow
+		rowErrs []RowError
+	)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %w", ErrInvalidImport, err)
+		}
+		line, _ := reader.FieldPos(0)
+		if len(rows)+len(rowErrs) == maxImportRows {
+			return nil, nil, fmt.Errorf("%w: more than %d rows", ErrInvalidImport, maxImportRows)
+		}
+		if len(record) != len(columns) {
+			rowErrs = append(rowErrs, RowError{Row: line, Error: fmt.Sprintf("has %d fields, want %d", len(record), len(columns))})
+			continue
+		}
+
+		var job jobs.Job
+		var errs []error
+		for i, value := range record {
+			if set := columns[i].set; set != nil {
+				if err := set(&job, strings.TrimSpace(value)); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		}
+		if err := errors.Join(errs...); err != nil {
+			rowErrs = append(rowErrs, RowError{Row: line, Error: err.Error()})
+			continue
+		}
+		rows = append(rows, importRow{row: line, job: job})
+	}
+	return rows, rowErrs, nil
+}
+
+func decodeNDJSON(r io.Reader) ([]importRow, []RowError, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64<<10), maxImportLineSize)
+
+	var (
+		rows    []importRow
+		rowErrs []RowError
+	)
+	for line := 1; scanner.Scan(); line++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+		if len(rows)+len(rowErrs) == maxImportRows {
+			return nil, nil, fmt.Errorf("%w: more than %d rows", ErrInvalidImport, maxImportRows)
+		}
+
+		var job jobs.Job
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&job); err != nil {
+			rowErrs = append(rowErrs, RowError{Row: line, Error: err.Error()})
+			continue
+		}
+		rows = append(rows, importRow{row: line, job: job})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidImport, err)
+	}
+	return rows, rowErrs, nil
+}
+
+// prepareImport validates an imported job and resets the fields the
+// server manages, so it is stored like a freshly scraped job.
+func prepareImport(job *jobs.Job) error {
+	job.Title = strings.TrimSpace(job.Title)
+	job.Company = strings.TrimSpace(job.Company)
+	job.SourceID = strings.TrimSpace(job.SourceID)
+	job.URL = strings.TrimSpace(job.URL)
+	job.Seniority = strings.ToLower(strings.TrimSpace(job.Seniority))
+
+	var errs []error
+	if job.Title == "" {
+		errs = append(errs, errors.New("title is required"))
+	}
+	if job.Company == "" {
+		errs = append(errs, errors.New("company is required"))
+	}
+	if job.SourceID == "" && job.URL == "" {
+		errs = append(errs, errors.New("sourceId or url is required"))
+	}
+	if job.URL != "" {
+		if u, err := url.Parse(job.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("url %q is not an http(s) URL", job.URL))
+		}
+	}
+	if job.Seniority != "" && !jobs.IsSeniority(job.Seniority) {
+		errs = append(errs, fmt.Errorf("unknown seniority %q", job.Seniority))
+	}
+	if job.EmploymentType != "" && jobs.NormalizeEmploymentType(job.EmploymentType) == "" {
+		errs = append(errs, fmt.Errorf("unknown employmentType %q", job.EmploymentType))
+	}
+	// Only the posted range is taken from a salaryInfo; its USD figures
+	// are converted again like a scraped salary's
+	if s := job.SalaryInfo; s != nil {
+		if job.SalaryInfo = jobs.NewSalaryInfo(s.Min, s.Max, s.Currency, s.Period); job.SalaryInfo == nil {
+			errs = append(errs, fmt.Errorf("salaryInfo needs a positive min or max and a known currency, got %s %v-%v", s.Currency, s.Min, s.Max))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	if job.SourceID == "" {
+		job.SourceID = job.URL
+	}
+	if job.Location == "" {
+		job.Location = "Remote"
+	}
+	if job.DateUnknown {
+		job.PostedAt = time.Time{}
+	}
+	*job = jobs.Job{
+		Title:          job.Title,
+		Company:        job.Company,
+		Description:    job.Description,
+		Skills:         job.Skills,
+		Salary:         job.Salary,
+		SalaryInfo:     job.SalaryInfo,
+		Location:       job.Location,
+		Source:         jobs.ImportSource,
+		URL:            job.URL,
+		SourceID:       job.SourceID,
+		PostedAt:       job.PostedAt,
+		ValidThrough:   job.ValidThrough,
+		Seniority:      job.Seniority,
+		EmploymentType: job.EmploymentType,
+		IsActive:       true,
+	}
+	return nil
+}
+
+// Import stores imported jobs the way a scrape run stores a source's jobs:
+// normalized, upserted by sourceId and clustered with duplicates. Imported
+// jobs are not expired for going missing, only once their validThrough
+// date passes.
+func (m *ScraperManager) Import(ctx context.Context, rows []importRow, rowErrs []RowError) (*ImportResult, error) {
+	result := &ImportResult{Rows: len(rows) + len(rowErrs), Errors: rowErrs}
+	result.Failed = len(rowErrs)
+	if len(rows) == 0 {
+		return result, nil
+	}
+	startedAt := time.Now()
+
+	list := make([]jobs.Job, len(rows))
+	rowOf := make(map[string]int, len(rows))
+	for i, row := range rows {
+		list[i] = row.job
+		rowOf[row.job.SourceID] = row.row
+	}
+	normalizeJobs(list)
+
+	stored, err := m.jobsRepo.BulkUpsert(ctx, list)
+	if stored != nil {
+		result.Added = stored.Added
+		result.Updated = stored.Updated
+		result.Unchanged = stored.Unchanged
+		result.Failed += stored.Failed
+		for _, itemErr := range stored.Errors {
+			result.Errors = append(result.Errors, RowError{Row: rowOf[itemErr.SourceID], Error: itemErr.Reason})
+		}
+		sort.Slice(result.Errors, func(i, j int) bool { return result.Errors[i].Row < result.Errors[j].Row })
+	}
+	if err != nil {
+		return result, err
+	}
+
+	if _, err := m.jobsRepo.ExpirePastValidThrough(ctx, jobs.ImportSource); err != nil {
+		return result, err
+	}
+	m.clusterDuplicates(startedAt)
+	return result, nil
+}
+
+// exportWriter writes jobs in an export format.
+type exportWriter interface {
+	Write(job *jobs.Job) error
+	Flush() error
+}
+
+func newExportWriter(w io.Writer, format string) (exportWriter, error) {
+	switch format {
+	case FormatCSV:
+		cw := &csvExportWriter{w: csv.NewWriter(w)}
+		header := make([]string, len(csvColumns))
+		for i, col := range csvColumns {
+			header[i] = col.name
+		}
+		return cw, cw.w.Write(header)
+	case FormatNDJSON:
+		enc := json.NewEncoder(w)
+		enc.SetEscapeHTML(false)
+		return &ndjsonExportWriter{enc: enc}, nil
+	}
+	return nil, fmt.Errorf("%w: format must be csv or ndjson", ErrInvalidImport)
+}
+
+type csvExportWriter struct {
+	w *csv.Writer
+}
+
+func (cw *csvExportWriter) Write(job *jobs.Job) error {
+	record := make([]string, len(csvColumns))
+	for i, col := range csvColumns {
+		record[i] = col.get(job)
+	}
+	return cw.w.Write(record)
+}
+
+func (cw *csvExportWriter) Flush() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+type ndjsonExportWriter struct {
+	enc *json.Encoder
+}
+
+func (nw *ndjsonExportWriter) Write(job *jobs.Job) error { return nw.enc.Encode(job) }
+
+func (nw *ndjsonExportWriter) Flush() error { return nil }
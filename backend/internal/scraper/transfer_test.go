@@ -0,0 +1,145 @@
+package scraper
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hiresense/backend/internal/jobs"
+)
+
+func TestDecodeImportCSV(t *testing.T) {
+	input := "Title,Company,Source ID,url,skills,seniority,postedAt\n" +
+		"Go Developer,Acme,acme-1,https://acme.example/jobs/1,go|postgres,Senior,2024-03-01\n" +
+		"No Company,,acme-2,,,,\n" +
+		"Rust Developer,Acme,,https://acme.example/jobs/3,,,\n" +
+		"Go Developer,Acme,acme-1,,,,\n" +
+		"Designer,Acme,acme-5,ftp://acme.example/5,,,someday\n"
+
+	rows, rowErrs, err := decodeImport(strings.NewReader(input), FormatCSV)
+	if err != nil {
+		t.Fatalf("decodeImport: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("decoded %d rows, want 2", len(rows))
+	}
+
+	job := rows[0].job
+	if rows[0].row != 2 || job.Source != jobs.ImportSource || job.SourceID != "acme-1" || !job.IsActive {
+		t.Errorf("row %d = %+v", rows[0].row, job)
+	}
+	if !reflect.DeepEqual(job.Skills, []string{"go", "postgres"}) || job.Seniority != "senior" || job.PostedAt.IsZero() {
+		t.Errorf("skills %v, seniority %q, postedAt %v", job.Skills, job.Seniority, job.PostedAt)
+	}
+	// Without a sourceId the URL identifies the job
+	if rows[1].job.SourceID != "https://acme.example/jobs/3" {
+		t.Errorf("sourceId = %q, want the url", rows[1].job.SourceID)
+	}
+
+	wantRows := []int{3, 5, 6}
+	if len(rowErrs) != len(wantRows) {
+		t.Fatalf("row errors = %+v", rowErrs)
+	}
+	for i, want := range wantRows {
+		if rowErrs[i].Row != want {
+			t.Errorf("row error %d is for row %d, want %d: %s", i, rowErrs[i].Row, want, rowErrs[i].Error)
+		}
+	}
+	if !strings.Contains(rowErrs[1].Error, "first seen in row 2") {
+		t.Errorf("duplicate error = %q", rowErrs[1].Error)
+	}
+	if !strings.Contains(rowErrs[2].Error, "postedAt") {
+		t.Errorf("row 6 error = %q", rowErrs[2].Error)
+	}
+}
+
+func TestDecodeImportRejectsFile(t *testing.T) {
+	tests := []struct{ format, input string }{
+		{FormatCSV, "title,company,colour\n"},
+		{FormatCSV, ""},
+		{"xml", "<jobs/>"},
+	}
+	for _, tt := range tests {
+		if _, _, err := decodeImport(strings.NewReader(tt.input), tt.format); !errors.Is(err, ErrInvalidImport) {
+			t.Errorf("decodeImport(%q, %q) = %v, want ErrInvalidImport", tt.format, tt.input, err)
+		}
+	}
+}
+
+func TestDecodeImportNDJSON(t *testing.T) {
+	input := `{"title":"Go Developer","company":"Acme","sourceId":"acme-1","source":"RemoteOK","isActive":false}` + "\n" +
+		"\n" +
+		`{"title":"Go Developer","company":"Acme","sourceId":"acme-2","colour":"blue"}` + "\n" +
+		`{"title":` + "\n" +
+		`{"title":"Go Developer","company":"Acme","sourceId":"acme-3","salaryInfo":{"min":50000,"currency":"XYZ","minUsd":900000}}` + "\n" +
+		`{"title":"Go Developer","company":"Acme","sourceId":"acme-4","salaryInfo":{"min":70000,"currency":"EUR","period":"year","minUsd":900000,"maxUsd":900000}}` + "\n"
+
+	rows, rowErrs, err := decodeImport(strings.NewReader(input), FormatNDJSON)
+	if err != nil {
+		t.Fatalf("decodeImport: %v", err)
+	}
+	if len(rows) != 2 || rows[0].job.Source != jobs.ImportSource || !rows[0].job.IsActive {
+		t.Fatalf("rows = %+v", rows)
+	}
+	if len(rowErrs) != 3 || rowErrs[0].Row != 3 || rowErrs[1].Row != 4 || rowErrs[2].Row != 5 {
+		t.Errorf("row errors = %+v", rowErrs)
+	}
+	// USD figures are converted again rather than trusted
+	if info := rows[1].job.SalaryInfo; info == nil || info.MinUSD != 75600 || info.MaxUSD != 75600 {
+		t.Errorf("salaryInfo = %+v", info)
+	}
+}
+
+func TestImportRoundTrip(t *testing.T) {
+	repo := jobs.NewMemoryRepository()
+	m := &ScraperManager{jobsRepo: repo}
+
+	input := "title,company,sourceId,url,description\n" +
+		"Go Developer,Acme,acme-1,https://acme.example/jobs/1,\"<p>Build APIs in Go</p>\"\n"
+	rows, rowErrs, err := decodeImport(strings.NewReader(input), FormatCSV)
+	if err != nil {
+		t.Fatalf("decodeImport: %v", err)
+	}
+	result, err := m.Import(context.Background(), rows, rowErrs)
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if result.Rows != 1 || result.Added != 1 || result.Failed != 0 {
+		t.Fatalf("result = %+v", result)
+	}
+
+	stored := repo.Jobs()
+	if len(stored) != 1 || stored[0].DescriptionText == "" || !containsFold(stored[0].Skills, "Go") {
+		t.Fatalf("stored %+v, want a normalized job", stored)
+	}
+
+	// Exporting and importing again changes nothing
+	for _, format := range []string{FormatCSV, FormatNDJSON} {
+		var buf bytes.Buffer
+		w, err := newExportWriter(&buf, format)
+		if err != nil {
+			t.Fatalf("newExportWriter(%s): %v", format, err)
+		}
+		if err := w.Write(&stored[0]); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Flush(); err != nil {
+			t.Fatal(err)
+		}
+
+		rows, rowErrs, err := decodeImport(&buf, format)
+		if err != nil || len(rowErrs) > 0 {
+			t.Fatalf("re-importing %s: %v %+v", format, err, rowErrs)
+		}
+		result, err := m.Import(context.Background(), rows, rowErrs)
+		if err != nil {
+			t.Fatalf("Import: %v", err)
+		}
+		if result.Unchanged != 1 {
+			t.Errorf("re-importing %s: %+v, want the job unchanged", format, result)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package scraper
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/hiresense/backend/internal/jobs"
+	"github.com/hiresense/backend/internal/sources"
+)
+
+// Default WeWorkRemotely category feeds
+var defaultWWRFeeds = []string{
+	"/categories/remote-full-stack-programming-jobs.rss",
+	"/categories/remote-back-end-programming-jobs.rss",
+	"/categories/remote-front-end-programming-jobs.rss",
+	"/categories/remote-devops-sysadmin-jobs.rss",
+	"/categories/remote-design-jobs.rss",
+	"/categories/remote-product-jobs.rss",
+}
+
+// WeWorkRemotely Scraper
+type WeWorkRemotelyScraper struct {
+	httpSource
+	feeds []string
+}
+
+func NewWeWorkRemotelyScraper() *WeWorkRemotelyScraper {
+	return &WeWorkRemotelyScraper{
+		httpSource: newHTTPSource("https://weworkremotely.com"),
+		feeds:      defaultWWRFeeds,
+	}
+}
+
+func (s *WeWorkRemotelyScraper) Name() string { return "WeWorkRemotely" }
+
+func (s *WeWorkRemotelyScraper) Settings() sources.Settings {
+	return sources.Settings{"feeds": s.feeds}
+}
+
+// Configure sets the category feeds to read, or the default ones when
+// none are given.
+func (s *WeWorkRemotelyScraper) Configure(settings sources.Settings) {
+	s.feeds = settings["feeds"]
+	if len(s.feeds) == 0 {
+		s.feeds = defaultWWRFeeds
+	}
+}
+
+type wwrFeed struct {
+	Channel struct {
+		Items []wwrItem `xml:"item"`
+	} `xml:"channel"`
+}
+
+type wwrItem struct {
+	Title       string `xml:"title"`
+	Region      string `xml:"region"`
+	Country     string `xml:"country"`
+	Skills      string `xml:"skills"`
+	Category    string `xml:"category"`
+	Type        string `xml:"type"`
+	Description string `xml:"description"`
+	PubDate     string `xml:"pubDate"`
+	GUID        string `xml:"guid"`
+	Link        string `xml:"link"`
+}
+
+// Scrape fetches every configured feed. A job listed in several categories
+// is only returned once, and a failing feed does not discard the others.
+func (s *WeWorkRemotelyScraper) Scrape(ctx context.Context) ([]jobs.Job, error) {
+	var (
+		result []jobs.Job
+		errs   []error
+	)
+	seen := make(map[string]bool)
+
+	for _, feed := range s.feeds {
+		feedJobs, err := s.scrapeFeed(ctx, feed)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", feed, err))
+			continue
+		}
+		for _, job := range feedJobs {
+			if seen[job.SourceID] {
+				continue
+			}
+			seen[job.SourceID] = true
+			result = append(result, job)
+		}
+	}
+
+	return result, errors.Join(errs...)
+}
+
+func (s *WeWorkRemotelyScraper) scrapeFeed(ctx context.Context, url string) ([]jobs.Job, error) {
+	body, err := s.get(ctx, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return parseWWRFeed(bytes.NewReader(body))
+}
+
+func parseWWRFeed(r io.Reader) ([]jobs.Job, error) {
+	var feed wwrFeed
+	if err := xml.NewDecoder(r).Decode(&feed); err != nil {
+		return nil, err
+	}
+
+	result := make([]jobs.Job, 0, len(feed.Channel.Items))
+	for _, item := range feed.Channel.Items {
+		// Titles are formatted as "Company: Role"
+		company, title, found := strings.Cut(item.Title, ":")
+		if !found {
+			continue
+		}
+
+		job := jobs.Job{
+			Title:       strings.TrimSpace(title),
+			Company:     strings.TrimSpace(company),
+			Description: strings.TrimSpace(item.Description),
+			Location:    wwrLocation(item),
+			URL:         strings.TrimSpace(item.Link),
+			Source:      "WeWorkRemotely",
+			SourceID:    strings.TrimSpace(item.GUID),
+			Skills:      parseSkills(item.Skills),
+			IsActive:    true,
+		}
+		if job.SourceID == "" {
+			job.SourceID = job.URL
+		}
+
+		if t, ok := parsePostedDate(item.PubDate); ok {
+			job.PostedAt = t
+		}
+
+		if job.Title != "" && job.Company != "" && job.SourceID != "" {
+			result = append(result, job)
+		}
+	}
+
+	return result, nil
+}
+
+func wwrLocation(item wwrItem) string {
+	region := strings.TrimSpace(item.Region)
+	country := strings.TrimSpace(item.Country)
+	switch {
+	case region == "" && country == "":
+		return "Remote"
+	case region == "":
+		return country
+	case country == "" || strings.Contains(region, country):
+		return region
+	default:
+		return region + " (" + country + ")"
+	}
+}
@@ -0,0 +1,81 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseWWRFeed(t *testing.T) {
+	f, err := os.Open("testdata/wwr_programming.rss")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	got, err := parseWWRFeed(f)
+	if err != nil {
+		t.Fatalf("parseWWRFeed: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d jobs, want 2", len(got))
+	}
+
+	job := got[0]
+	if job.Company != "Acme Analytics" || job.Title != "Senior Full-Stack Engineer" {
+		t.Errorf("company/title = %q/%q", job.Company, job.Title)
+	}
+	if job.Location != "Anywhere in the World" {
+		t.Errorf("location = %q", job.Location)
+	}
+	if job.Source != "WeWorkRemotely" || job.SourceID != "https://weworkremotely.com/remote-jobs/acme-analytics-senior-full-stack-engineer" {
+		t.Errorf("source/sourceId = %q/%q", job.Source, job.SourceID)
+	}
+	if want := []string{"React", "Go", "PostgreSQL"}; !reflect.DeepEqual(job.Skills, want) {
+		t.Errorf("skills = %v, want %v", job.Skills, want)
+	}
+	if want := time.Date(2024, 10, 16, 14, 12, 31, 0, time.UTC); !job.PostedAt.Equal(want) {
+		t.Errorf("postedAt = %v, want %v", job.PostedAt, want)
+	}
+	if job.Description == "" || !job.IsActive {
+		t.Errorf("description empty or job inactive: %+v", job)
+	}
+
+	if got[1].Location != "USA Only (United States)" {
+		t.Errorf("location = %q", got[1].Location)
+	}
+}
+
+func TestWeWorkRemotelyScraper(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/programming.rss", func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, "testdata/wwr_programming.rss")
+	})
+	mux.HandleFunc("/devops.rss", func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, "testdata/wwr_devops.rss")
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	s := NewWeWorkRemotelyScraper()
+	s.SetBaseURL(srv.URL)
+	s.feeds = []string{"/programming.rss", "/devops.rss", "/missing.rss"}
+
+	got, err := s.Scrape(context.Background())
+	if err == nil {
+		t.Error("expected an error for the missing feed")
+	}
+
+	// The Acme listing appears in both feeds but must only be returned once
+	if len(got) != 3 {
+		t.Fatalf("got %d jobs, want 3", len(got))
+	}
+	last := got[2]
+	if last.Company != "Globex" || last.Title != "Site Reliability Engineer: Kubernetes" {
+		t.Errorf("company/title = %q/%q", last.Company, last.Title)
+	}
+}
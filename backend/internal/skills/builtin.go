@@ -0,0 +1,145 @@
+package skills
+
+// Categories
+const (
+	Frontend = "Frontend"
+	Backend  = "Backend"
+	Mobile   = "Mobile"
+	Data     = "Data"
+	DevOps   = "DevOps"
+	Cloud    = "Cloud"
+	Design   = "Design"
+	QA       = "QA"
+	Security = "Security"
+)
+
+var builtinSkills = []Skill{
+	{Name: Frontend, Aliases: []string{"front-end", "front end", "frontend development"}},
+	{Name: Backend, Aliases: []string{"back-end", "back end", "backend development"}},
+	{Name: "Full Stack", Aliases: []string{"fullstack", "full-stack"}},
+	{Name: Mobile, Aliases: []string{"mobile development"}},
+	{Name: Data, Aliases: []string{"data engineering"}},
+	{Name: DevOps, Aliases: []string{"dev ops", "sre", "site reliability"}},
+	{Name: Cloud},
+	{Name: Design, Aliases: []string{"ui/ux", "ux", "ui", "product design"}},
+	{Name: QA, Aliases: []string{"testing", "quality assurance", "test automation"}},
+	{Name: Security, Aliases: []string{"infosec", "cybersecurity", "appsec"}},
+
+	// Frontend
+	{Name: "JavaScript", Parent: Frontend, Aliases: []string{"js", "javascript es6", "es6", "ecmascript"}},
+	{Name: "TypeScript", Parent: Frontend, Aliases: []string{"ts"}},
+	{Name: "React", Parent: Frontend, Aliases: []string{"reactjs", "react.js", "react js"}},
+	{Name: "Next.js", Parent: Frontend, Aliases: []string{"nextjs", "next js"}},
+	{Name: "Vue", Parent: Frontend, Aliases: []string{"vuejs", "vue.js", "vue js"}},
+	{Name: "Angular", Parent: Frontend, Aliases: []string{"angularjs", "angular.js"}},
+	{Name: "Svelte", Parent: Frontend, Aliases: []string{"sveltekit"}},
+	{Name: "HTML", Parent: Frontend, Aliases: []string{"html5"}},
+	{Name: "CSS", Parent: Frontend, Aliases: []string{"css3", "sass", "scss"}},
+	{Name: "Tailwind", Parent: Frontend, Aliases: []string{"tailwindcss", "tailwind css"}},
+	{Name: "Redux", Parent: Frontend},
+
+	// Backend
+	{Name: "Go", Parent: Backend, Aliases: []string{"golang"}},
+	{Name: "Python", Parent: Backend, Aliases: []string{"python3"}},
+	{Name: "Java", Parent: Backend},
+	{Name: "Kotlin", Parent: Backend},
+	{Name: "Scala", Parent: Backend},
+	{Name: "Ruby", Parent: Backend},
+	{Name: "Ruby on Rails", Parent: Backend, Aliases: []string{"rails", "ror"}},
+	{Name: "PHP", Parent: Backend},
+	{Name: "Laravel", Parent: Backend},
+	{Name: "C#", Parent: Backend, Aliases: []string{"csharp", "c sharp"}},
+	{Name: ".NET", Parent: Backend, Aliases: []string{"dotnet", "asp.net", ".net core"}},
+	{Name: "Rust", Parent: Backend},
+	{Name: "Elixir", Parent: Backend},
+	{Name: "C++", Parent: Backend, Aliases: []string{"cpp"}},
+	{Name: "Node.js", Parent: Backend, Aliases: []string{"node", "nodejs", "node js"}},
+	{Name: "Django", Parent: Backend},
+	{Name: "Flask", Parent: Backend},
+	{Name: "FastAPI", Parent: Backend},
+	{Name: "Spring", Parent: Backend, Aliases: []string{"spring boot", "springboot"}},
+	{Name: "GraphQL", Parent: Backend},
+	{Name: "REST", Parent: Backend, Aliases: []string{"rest api", "restful"}},
+	{Name: "gRPC", Parent: Backend},
+
+	// Mobile
+	{Name: "iOS", Parent: Mobile},
+	{Name: "Android", Parent: Mobile},
+	{Name: "Swift", Parent: Mobile},
+	{Name: "React Native", Parent: Mobile, Aliases: []string{"react-native"}},
+	{Name: "Flutter", Parent: Mobile},
+	{Name: "Dart", Parent: Mobile},
+
+	// Data
+	{Name: "SQL", Parent: Data},
+	{Name: "PostgreSQL", Parent: Data, Aliases: []string{"postgres", "psql"}},
+	{Name: "MySQL", Parent: Data},
+	{Name: "MongoDB", Parent: Data, Aliases: []string{"mongo"}},
+	{Name: "Redis", Parent: Data},
+	{Name: "Elasticsearch", Parent: Data, Aliases: []string{"elastic search", "opensearch"}},
+	{Name: "Kafka", Parent: Data, Aliases: []string{"apache kafka"}},
+	{Name: "Spark", Parent: Data, Aliases: []string{"apache spark", "pyspark"}},
+	{Name: "Machine Learning", Parent: Data, Aliases: []string{"ml"}},
+	{Name: "Deep Learning", Parent: Data},
+	{Name: "AI", Parent: Data, Aliases: []string{"artificial intelligence", "genai", "llm", "llms"}},
+	{Name: "Data Science", Parent: Data},
+	{Name: "Pandas", Parent: Data},
+	{Name: "PyTorch", Parent: Data},
+	{Name: "TensorFlow", Parent: Data},
+	{Name: "Snowflake", Parent: Data},
+	{Name: "dbt", Parent: Data},
+	{Name: "Airflow", Parent: Data, Aliases: []string{"apache airflow"}},
+
+	// DevOps and cloud
+	{Name: "Docker", Parent: DevOps},
+	{Name: "Kubernetes", Parent: DevOps, Aliases: []string{"k8s"}},
+	{Name: "Terraform", Parent: DevOps},
+	{Name: "Ansible", Parent: DevOps},
+	{Name: "CI/CD", Parent: DevOps, Aliases: []string{"ci", "cicd", "ci cd", "continuous integration"}},
+	{Name: "Linux", Parent: DevOps},
+	{Name: "AWS", Parent: Cloud, Aliases: []string{"amazon web services"}},
+	{Name: "GCP", Parent: Cloud, Aliases: []string{"google cloud", "google cloud platform"}},
+	{Name: "Azure", Parent: Cloud, Aliases: []string{"microsoft azure"}},
+
+	// Design
+	{Name: "Figma", Parent: Design},
+	{Name: "Sketch", Parent: Design},
+
+	// QA
+	{Name: "Cypress", Parent: QA},
+	{Name: "Selenium", Parent: QA},
+	{Name: "Playwright", Parent: QA},
+	{Name: "Jest", Parent: QA},
+}
+
+// exactSurfaces lists skill names that are also ordinary words. The
+// extractor only picks them out of free text when written exactly as
+// shown, so "Go" counts but "let's go" does not.
+var exactSurfaces = map[string][]string{
+	"go":         {"Go"},
+	"react":      {"React"},
+	"rest":       {"REST"},
+	"swift":      {"Swift"},
+	"spring":     {"Spring"},
+	"spark":      {"Spark"},
+	"rust":       {"Rust"},
+	"dart":       {"Dart"},
+	"sketch":     {"Sketch"},
+	"jest":       {"Jest"},
+	"flask":      {"Flask"},
+	"ruby":       {"Ruby"},
+	"rails":      {"Rails"},
+	"node":       {"Node"},
+	"snowflake":  {"Snowflake"},
+	"airflow":    {"Airflow"},
+	"playwright": {"Playwright"},
+	"cypress":    {"Cypress"},
+	"ai":         {"AI"},
+	"ml":         {"ML"},
+	"ci":         {"CI"},
+	"ts":         {"TS"},
+	"ui":         {"UI"},
+	"ux":         {"UX"},
+	"sre":        {"SRE"},
+	"ror":        {"RoR"},
+}
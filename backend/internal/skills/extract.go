@@ -0,0 +1,104 @@
+package skills
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// maxPhraseWords is the longest skill name, in words, the extractor tries.
+const maxPhraseWords = 4
+
+var htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// Extract returns the canonical skills mentioned in text, in order of
+// first mention. Names and aliases are matched on whole words, longest
+// phrase first, so "React Native" is not also counted as React. Categories
+// such as "Frontend" are too vague to infer and are never returned.
+func (t *Taxonomy) Extract(text string) []string {
+	text = html.UnescapeString(htmlTagPattern.ReplaceAllString(text, " "))
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	words := t.tokenize(text)
+
+	var result []string
+	seen := make(map[string]bool)
+	for i := 0; i < len(words); {
+		name, n := t.matchAt(words, i)
+		if n == 0 {
+			i++
+			continue
+		}
+		i += n
+
+		k := key(name)
+		if seen[k] || len(t.children[k]) > 0 {
+			continue
+		}
+		seen[k] = true
+		result = append(result, name)
+	}
+	return result
+}
+
+// matchAt finds the longest skill starting at words[i] and returns its
+// canonical name and length in words, or 0 if there is none.
+func (t *Taxonomy) matchAt(words []string, i int) (string, int) {
+	for n := min(maxPhraseWords, len(words)-i); n > 0; n-- {
+		surface := strings.Join(words[i:i+n], " ")
+		phrase := key(surface)
+
+		name, ok := t.lookup[phrase]
+		if !ok {
+			continue
+		}
+		if exact, restricted := exactSurfaces[phrase]; restricted && !contains(exact, surface) {
+			continue
+		}
+		return name, n
+	}
+	return "", 0
+}
+
+// tokenize splits text into words, keeping the punctuation that belongs
+// to skill names such as "C#", "C++", ".NET" and "Node.js". Words joined
+// by a slash are split unless the whole is a known name like "CI/CD".
+func (t *Taxonomy) tokenize(text string) []string {
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return unicode.IsSpace(r) || strings.ContainsRune(",;:()[]{}!?\"'`|*•<>", r)
+	})
+
+	words := make([]string, 0, len(fields))
+	for _, f := range fields {
+		f = strings.TrimLeft(strings.TrimRight(f, ".-/"), "-/")
+		if f == "" {
+			continue
+		}
+		if strings.Contains(f, "/") {
+			if _, known := t.lookup[key(f)]; !known {
+				for _, part := range strings.Split(f, "/") {
+					if part != "" {
+						words = append(words, part)
+					}
+				}
+				continue
+			}
+		}
+		words = append(words, f)
+	}
+	return words
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func Extract(text string) []string { return Default.Extract(text) }
@@ -0,0 +1,27 @@
+package skills
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtract(t *testing.T) {
+	tax := NewTaxonomy(builtinSkills)
+
+	text := `<p>We&#39;re hiring a Senior Engineer to work on our Go services and
+	React Native apps.</p><ul><li>Experience with PostgreSQL/Redis and k8s</li>
+	<li>CI/CD, C#, C++ or .NET a plus.</li><li>Familiarity with machine learning.</li></ul>
+	<p>Let's go! We react quickly, rest well and work from the front end of the market.</p>
+	Bonus: golang, GO, node.js.`
+
+	got := tax.Extract(text)
+	want := []string{"Go", "React Native", "PostgreSQL", "Redis", "Kubernetes", "CI/CD", "C#", "C++", ".NET", "Machine Learning", "Node.js"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Extract =\n  %v\nwant\n  %v", got, want)
+	}
+
+	// Deterministic across calls
+	if again := tax.Extract(text); !reflect.DeepEqual(again, got) {
+		t.Errorf("second Extract = %v", again)
+	}
+}
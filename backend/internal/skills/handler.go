@@ -0,0 +1,103 @@
+package skills
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	repo     *Repository
+	taxonomy *Taxonomy
+}
+
+func NewHandler() *Handler {
+	h := &Handler{
+		repo:     NewRepository(),
+		taxonomy: Default,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := h.repo.Load(ctx, h.taxonomy); err != nil {
+		log.Printf("⚠️ Failed to load skill aliases: %v", err)
+	}
+	return h
+}
+
+func (h *Handler) RegisterRoutes(r *gin.RouterGroup) {
+	r.GET("/skills", h.GetSkills)
+	r.GET("/skills/aliases", h.GetAliases)
+	r.PUT("/skills/aliases/:alias", h.SaveAlias)
+	r.DELETE("/skills/aliases/:alias", h.DeleteAlias)
+}
+
+func (h *Handler) GetSkills(c *gin.Context) {
+	c.JSON(http.StatusOK, h.taxonomy.Skills())
+}
+
+func (h *Handler) GetAliases(c *gin.Context) {
+	aliases, err := h.repo.All(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch aliases"})
+		return
+	}
+	c.JSON(http.StatusOK, aliases)
+}
+
+type SaveAliasRequest struct {
+	Skill string `json:"skill" binding:"required"`
+}
+
+func (h *Handler) SaveAlias(c *gin.Context) {
+	var req SaveAliasRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	alias := Alias{Alias: key(c.Param("alias")), Skill: strings.TrimSpace(req.Skill)}
+	if alias.Alias == "" || alias.Skill == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Alias and skill are required"})
+		return
+	}
+	if h.taxonomy.IsCanonical(alias.Alias) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Alias is already a canonical skill"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := h.repo.Save(ctx, &alias); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save alias"})
+		return
+	}
+	if err := h.repo.Load(ctx, h.taxonomy); err != nil {
+		log.Printf("⚠️ Failed to reload skill aliases: %v", err)
+	}
+
+	c.JSON(http.StatusOK, Alias{Alias: alias.Alias, Skill: h.taxonomy.Normalize(alias.Alias)})
+}
+
+func (h *Handler) DeleteAlias(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	err := h.repo.Delete(ctx, key(c.Param("alias")))
+	if errors.Is(err, ErrAliasNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Alias not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete alias"})
+		return
+	}
+	if err := h.repo.Load(ctx, h.taxonomy); err != nil {
+		log.Printf("⚠️ Failed to reload skill aliases: %v", err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Alias deleted"})
+}
@@ -0,0 +1,65 @@
+package skills
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hiresense/backend/internal/config"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var ErrAliasNotFound = errors.New("alias not found")
+
+// Repository stores the custom skill aliases added by admins.
+type Repository struct {
+	aliases *mongo.Collection
+}
+
+func NewRepository() *Repository {
+	return &Repository{
+		aliases: config.GetCollection("skill_aliases"),
+	}
+}
+
+func (r *Repository) All(ctx context.Context) ([]Alias, error) {
+	cursor, err := r.aliases.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	aliases := []Alias{}
+	if err := cursor.All(ctx, &aliases); err != nil {
+		return nil, err
+	}
+	return aliases, nil
+}
+
+func (r *Repository) Save(ctx context.Context, alias *Alias) error {
+	opts := options.Replace().SetUpsert(true)
+	_, err := r.aliases.ReplaceOne(ctx, bson.M{"_id": alias.Alias}, alias, opts)
+	return err
+}
+
+func (r *Repository) Delete(ctx context.Context, alias string) error {
+	result, err := r.aliases.DeleteOne(ctx, bson.M{"_id": alias})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrAliasNotFound
+	}
+	return nil
+}
+
+// Load replaces the custom aliases of t with the stored ones.
+func (r *Repository) Load(ctx context.Context, t *Taxonomy) error {
+	aliases, err := r.All(ctx)
+	if err != nil {
+		return err
+	}
+	t.SetAliases(aliases)
+	return nil
+}
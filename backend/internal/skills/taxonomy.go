@@ -0,0 +1,212 @@
+package skills
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Skill is a canonical skill name with the spellings that map to it.
+// Parent is the broader category it belongs to, such as "Frontend" for
+// "React"; categories are skills themselves.
+type Skill struct {
+	Name    string   `json:"name"`
+	Parent  string   `json:"parent,omitempty"`
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+// Alias maps an extra spelling to a canonical skill. Builtin aliases ship
+// with the taxonomy; custom ones are managed by admins.
+type Alias struct {
+	Alias string `json:"alias" bson:"_id"`
+	Skill string `json:"skill" bson:"skill"`
+}
+
+// Taxonomy resolves free-form skill names to canonical ones. It is safe
+// for concurrent use.
+type Taxonomy struct {
+	builtin []Skill
+
+	mu       sync.RWMutex
+	skills   map[string]*Skill   // key(name) -> skill
+	lookup   map[string]string   // key(name or alias) -> canonical name
+	children map[string][]string // key(name) -> child names
+}
+
+func NewTaxonomy(builtin []Skill) *Taxonomy {
+	t := &Taxonomy{builtin: builtin}
+	t.SetAliases(nil)
+	return t
+}
+
+// key folds case and whitespace so "Node JS" and "node  js" look the same.
+func key(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// SetAliases replaces the custom aliases. An alias may name a skill the
+// builtin taxonomy does not know, which then becomes a new canonical skill.
+// Aliases that collide with a canonical skill name are ignored.
+func (t *Taxonomy) SetAliases(custom []Alias) {
+	skills := make(map[string]*Skill, len(t.builtin))
+	lookup := make(map[string]string)
+	children := make(map[string][]string)
+
+	for _, s := range t.builtin {
+		skill := s
+		skill.Aliases = append([]string(nil), s.Aliases...)
+		skills[key(skill.Name)] = &skill
+		lookup[key(skill.Name)] = skill.Name
+	}
+	for _, skill := range skills {
+		for _, alias := range skill.Aliases {
+			lookup[key(alias)] = skill.Name
+		}
+		if skill.Parent != "" {
+			parent := key(skill.Parent)
+			children[parent] = append(children[parent], skill.Name)
+		}
+	}
+
+	for _, a := range custom {
+		aliasKey := key(a.Alias)
+		if aliasKey == "" || skills[aliasKey] != nil {
+			continue
+		}
+		target, ok := skills[key(a.Skill)]
+		if !ok {
+			if name, isAlias := lookup[key(a.Skill)]; isAlias {
+				target = skills[key(name)]
+			} else {
+				target = &Skill{Name: strings.TrimSpace(a.Skill)}
+				skills[key(target.Name)] = target
+				lookup[key(target.Name)] = target.Name
+			}
+		}
+		// A custom alias can move a builtin one to another skill
+		if prior, ok := lookup[aliasKey]; ok {
+			owner := skills[key(prior)]
+			owner.Aliases = removeKey(owner.Aliases, aliasKey)
+		}
+		target.Aliases = append(target.Aliases, a.Alias)
+		lookup[aliasKey] = target.Name
+	}
+
+	for _, names := range children {
+		sort.Strings(names)
+	}
+
+	t.mu.Lock()
+	t.skills, t.lookup, t.children = skills, lookup, children
+	t.mu.Unlock()
+}
+
+func removeKey(list []string, k string) []string {
+	result := list[:0]
+	for _, s := range list {
+		if key(s) != k {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// Normalize returns the canonical name for skill, or the trimmed input if
+// the taxonomy does not know it.
+func (t *Taxonomy) Normalize(skill string) string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if name, ok := t.lookup[key(skill)]; ok {
+		return name
+	}
+	return strings.TrimSpace(skill)
+}
+
+// NormalizeAll normalizes a list of skills, dropping blanks and
+// duplicates while keeping the original order.
+func (t *Taxonomy) NormalizeAll(list []string) []string {
+	result := make([]string, 0, len(list))
+	seen := make(map[string]bool, len(list))
+	for _, s := range list {
+		name := t.Normalize(s)
+		if name == "" || seen[key(name)] {
+			continue
+		}
+		seen[key(name)] = true
+		result = append(result, name)
+	}
+	return result
+}
+
+// Parent returns the category of a skill, or "" if it has none.
+func (t *Taxonomy) Parent(skill string) string {
+	name := t.Normalize(skill)
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if s, ok := t.skills[key(name)]; ok {
+		return s.Parent
+	}
+	return ""
+}
+
+// Expand normalizes skills and adds every skill below each one in the
+// taxonomy, so filtering by "Frontend" also finds jobs asking for React.
+func (t *Taxonomy) Expand(list []string) []string {
+	normalized := t.NormalizeAll(list)
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	result := make([]string, 0, len(normalized))
+	seen := make(map[string]bool)
+	var walk func(name string)
+	walk = func(name string) {
+		if seen[key(name)] {
+			return
+		}
+		seen[key(name)] = true
+		result = append(result, name)
+		for _, child := range t.children[key(name)] {
+			walk(child)
+		}
+	}
+	for _, name := range normalized {
+		walk(name)
+	}
+	return result
+}
+
+// Skills returns every canonical skill sorted by name.
+func (t *Taxonomy) Skills() []Skill {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	result := make([]Skill, 0, len(t.skills))
+	for _, s := range t.skills {
+		skill := *s
+		skill.Aliases = append([]string(nil), s.Aliases...)
+		result = append(result, skill)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// IsCanonical reports whether name is a canonical skill rather than an
+// alias or an unknown spelling.
+func (t *Taxonomy) IsCanonical(name string) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	_, ok := t.skills[key(name)]
+	return ok
+}
+
+// Default is the taxonomy used across the app, seeded with the builtin
+// skills and extended by the aliases stored in MongoDB.
+var Default = NewTaxonomy(builtinSkills)
+
+func Normalize(skill string) string       { return Default.Normalize(skill) }
+func NormalizeAll(list []string) []string { return Default.NormalizeAll(list) }
+func Expand(list []string) []string       { return Default.Expand(list) }
+func Parent(skill string) string          { return Default.Parent(skill) }
@@ -0,0 +1,80 @@
+package skills
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeAll(t *testing.T) {
+	tax := NewTaxonomy(builtinSkills)
+
+	got := tax.NormalizeAll([]string{"golang", "ReactJS", " k8s ", "Go", "", "Elm", "elm"})
+	want := []string{"Go", "React", "Kubernetes", "Elm"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NormalizeAll = %v, want %v", got, want)
+	}
+	if parent := tax.Parent("react.js"); parent != Frontend {
+		t.Errorf("Parent(react.js) = %q, want %q", parent, Frontend)
+	}
+}
+
+func TestExpand(t *testing.T) {
+	tax := NewTaxonomy([]Skill{
+		{Name: "Frontend"},
+		{Name: "React", Parent: "Frontend", Aliases: []string{"reactjs"}},
+		{Name: "Redux", Parent: "React"},
+		{Name: "Vue", Parent: "Frontend"},
+		{Name: "Go"},
+	})
+
+	got := tax.Expand([]string{"front end", "reactjs", "Go"})
+	want := []string{"front end", "React", "Redux", "Go"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expand = %v, want %v", got, want)
+	}
+
+	got = tax.Expand([]string{"frontend"})
+	want = []string{"Frontend", "React", "Redux", "Vue"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expand = %v, want %v", got, want)
+	}
+}
+
+func TestSetAliases(t *testing.T) {
+	tax := NewTaxonomy(builtinSkills)
+	tax.SetAliases([]Alias{
+		{Alias: "gopher", Skill: "golang"},
+		{Alias: "ts", Skill: "TypeScript"},
+		{Alias: "node", Skill: "Deno"},
+		{Alias: "Python", Skill: "Go"},
+	})
+
+	for in, want := range map[string]string{
+		"Gopher": "Go",
+		"node":   "Deno",
+		"deno":   "Deno",
+		"python": "Python",
+	} {
+		if got := tax.Normalize(in); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", in, got, want)
+		}
+	}
+	for _, s := range tax.Skills() {
+		if s.Name == "Node.js" {
+			for _, alias := range s.Aliases {
+				if alias == "node" {
+					t.Error("node is still listed as a Node.js alias")
+				}
+			}
+		}
+	}
+
+	// Replacing the custom aliases drops the old ones
+	tax.SetAliases(nil)
+	if got := tax.Normalize("gopher"); got != "gopher" {
+		t.Errorf("Normalize(gopher) after reset = %q", got)
+	}
+	if got := tax.Normalize("node"); got != "Node.js" {
+		t.Errorf("Normalize(node) after reset = %q", got)
+	}
+}
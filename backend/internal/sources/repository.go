@@ -0,0 +1,137 @@
+package sources
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hiresense/backend/internal/config"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Repository stores the source registry.
+type Repository struct {
+	sources *mongo.Collection
+}
+
+func NewRepository() *Repository {
+	return &Repository{
+		sources: config.GetCollection("sources"),
+	}
+}
+
+// Seed adds the given sources unless they are already registered or were
+// deleted, so changes made by admins are kept.
+func (r *Repository) Seed(ctx context.Context, defaults []Source) error {
+	now := time.Now()
+	for _, src := range defaults {
+		_, err := r.sources.UpdateOne(ctx, bson.M{"_id": src.ID}, bson.M{
+			"$setOnInsert": bson.M{
+				"displayName": src.DisplayName,
+				"logoUrl":     src.LogoURL,
+				"enabled":     src.Enabled,
+				"settings":    src.Settings,
+				"createdAt":   now,
+				"updatedAt":   now,
+			},
+		}, options.Update().SetUpsert(true))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// notDeleted matches the sources that have not been deleted.
+var notDeleted = bson.M{"deletedAt": bson.M{"$exists": false}}
+
+func (r *Repository) All(ctx context.Context) ([]Source, error) {
+	return r.find(ctx, notDeleted)
+}
+
+// Enabled returns the sources that are scraped and shown to users.
+func (r *Repository) Enabled(ctx context.Context) ([]Source, error) {
+	return r.find(ctx, bson.M{"enabled": true, "deletedAt": bson.M{"$exists": false}})
+}
+
+func (r *Repository) find(ctx context.Context, filter bson.M) ([]Source, error) {
+	cursor, err := r.sources.Find(ctx, filter, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	sources := []Source{}
+	if err := cursor.All(ctx, &sources); err != nil {
+		return nil, err
+	}
+	return sources, nil
+}
+
+func (r *Repository) FindByID(ctx context.Context, id string) (*Source, error) {
+	var src Source
+	err := r.sources.FindOne(ctx, bson.M{"_id": id, "deletedAt": bson.M{"$exists": false}}).Decode(&src)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrSourceNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &src, nil
+}
+
+// Create registers a source, replacing its tombstone if it was deleted.
+func (r *Repository) Create(ctx context.Context, src *Source) error {
+	now := time.Now()
+	src.CreatedAt, src.UpdatedAt = now, now
+
+	// A live source with the same ID misses the filter, and the upsert
+	// then collides with it
+	_, err := r.sources.ReplaceOne(ctx,
+		bson.M{"_id": src.ID, "deletedAt": bson.M{"$exists": true}},
+		src, options.Replace().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrSourceExists
+	}
+	return err
+}
+
+// Update replaces a source's name, logo, state and settings.
+func (r *Repository) Update(ctx context.Context, src *Source) (*Source, error) {
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var updated Source
+	err := r.sources.FindOneAndUpdate(ctx, bson.M{"_id": src.ID, "deletedAt": bson.M{"$exists": false}}, bson.M{
+		"$set": bson.M{
+			"displayName": src.DisplayName,
+			"logoUrl":     src.LogoURL,
+			"enabled":     src.Enabled,
+			"settings":    src.Settings,
+			"updatedAt":   time.Now(),
+		},
+	}, opts).Decode(&updated)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrSourceNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// Delete leaves a tombstone in place of the source.
+func (r *Repository) Delete(ctx context.Context, id string) error {
+	now := time.Now()
+	result, err := r.sources.UpdateOne(ctx,
+		bson.M{"_id": id, "deletedAt": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"enabled": false, "deletedAt": now, "updatedAt": now}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrSourceNotFound
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package sources
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	ErrSourceNotFound = errors.New("source not found")
+	ErrSourceExists   = errors.New("source already exists")
+	ErrInvalidSource  = errors.New("invalid source")
+)
+
+// Source is a job source in the registry. Its ID is the name of the
+// scraper that reads it, which is also the source stored on its jobs.
+type Source struct {
+	ID          string    `json:"id" bson:"_id"`
+	DisplayName string    `json:"displayName" bson:"displayName"`
+	LogoURL     string    `json:"logoUrl,omitempty" bson:"logoUrl,omitempty"`
+	Enabled     bool      `json:"enabled" bson:"enabled"`
+	Settings    Settings  `json:"settings" bson:"settings"`
+	CreatedAt   time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt" bson:"updatedAt"`
+
+	// Deleted sources are kept as tombstones so seeding does not bring
+	// them back
+	DeletedAt *time.Time `json:"-" bson:"deletedAt,omitempty"`
+}
+
+// Settings are source-specific options, such as the companies to read
+// from a job board. Every setting is a list of strings.
+type Settings map[string][]string
+
+// Clean returns the settings with values trimmed and empty values dropped,
+// rejecting any key not in accepted.
+func (s Settings) Clean(accepted Settings) (Settings, error) {
+	cleaned := make(Settings, len(accepted))
+	for key := range accepted {
+		cleaned[key] = []string{}
+	}
+	for key, values := range s {
+		if _, ok := accepted[key]; !ok {
+			return nil, fmt.Errorf("%w: unknown setting %q (accepted: %s)", ErrInvalidSource, key, accepted.keys())
+		}
+		for _, v := range values {
+			if v = strings.TrimSpace(v); v != "" {
+				cleaned[key] = append(cleaned[key], v)
+			}
+		}
+	}
+	return cleaned, nil
+}
+
+func (s Settings) keys() string {
+	if len(s) == 0 {
+		return "none"
+	}
+	keys := make([]string, 0, len(s))
+	for key := range s {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ", ")
+}
@@ -2,8 +2,11 @@ package users
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/hiresense/backend/internal/location"
+	"github.com/hiresense/backend/internal/skills"
 )
 
 type Handler struct {
@@ -30,6 +33,8 @@ type UpdateProfileRequest struct {
 	SalaryRange      SalaryRange `json:"salaryRange"`
 	RemotePreference string      `json:"remotePreference"`
 	PreferredRoles   []string    `json:"preferredRoles"`
+	Country          string      `json:"country"`
+	Timezone         string      `json:"timezone"`
 }
 
 func (h *Handler) GetProfile(c *gin.Context) {
@@ -53,12 +58,20 @@ func (h *Handler) UpdateProfile(c *gin.Context) {
 		return
 	}
 
+	pref, err := location.NewPreference(req.RemotePreference, req.Country, "", req.Timezone)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	profile := &Profile{
-		Skills:           req.Skills,
+		Skills:           skills.NormalizeAll(req.Skills),
 		ExperienceLevel:  req.ExperienceLevel,
 		SalaryRange:      req.SalaryRange,
 		RemotePreference: req.RemotePreference,
 		PreferredRoles:   req.PreferredRoles,
+		Country:          pref.Country,
+		Timezone:         strings.TrimSpace(req.Timezone),
 	}
 
 	user, err := h.repo.UpdateProfile(c.Request.Context(), userID, profile)
@@ -17,6 +17,11 @@ type Profile struct {
 	SalaryRange      SalaryRange `json:"salaryRange" bson:"salaryRange"`
 	RemotePreference string      `json:"remotePreference" bson:"remotePreference"`
 	PreferredRoles   []string    `json:"preferredRoles" bson:"preferredRoles"`
+
+	// Where the user works from, matched against job location
+	// restrictions: an ISO country code and a UTC offset or IANA timezone
+	Country  string `json:"country,omitempty" bson:"country,omitempty"`
+	Timezone string `json:"timezone,omitempty" bson:"timezone,omitempty"`
 }
 
 type User struct {
@@ -100,6 +100,10 @@ func (r *Repository) UpdateProfile(ctx context.Context, id string, profile *Prof
 	return r.FindByID(ctx, id)
 }
 
+func (r *Repository) Count(ctx context.Context) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{})
+}
+
 func (r *Repository) Delete(ctx context.Context, id string) error {
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
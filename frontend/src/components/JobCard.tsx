@@ -151,6 +151,11 @@ export const JobCard = ({
                     {job.salary && (
                         <p style={{ marginTop: '8px', color: '#2dd4bf', fontWeight: 500, fontSize: '14px' }}>{job.salary}</p>
                     )}
+                    {job.salaryChanged && (
+                        <p style={{ marginTop: '4px', color: '#fbbf24', fontSize: '12px' }}>
+                            Salary changed since you saved it{job.previousSalary ? ` (was ${job.previousSalary})` : ''}
+                        </p>
+                    )}
                 </div>
 
                 {/* Right Side - Score and Actions */}
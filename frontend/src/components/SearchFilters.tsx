@@ -1,12 +1,12 @@
 import { useState, useMemo, useEffect } from 'react';
 import { Search, Filter, X, ChevronDown } from 'lucide-react';
-import type { JobFilters } from '@/types';
+import type { JobFilters, JobSource } from '@/types';
 import { useDebounce } from '@/hooks';
 
 interface SearchFiltersProps {
     filters: JobFilters;
     onChange: (filters: JobFilters) => void;
-    sources?: string[];
+    sources?: JobSource[];
 }
 
 const experienceLevels = [
@@ -163,8 +163,8 @@ export const SearchFilters = ({ filters, onChange, sources = [] }: SearchFilters
                             >
                                 <option value="" style={{ background: '#1e293b' }}>All Sources</option>
                                 {sources.map((source) => (
-                                    <option key={source} value={source} style={{ background: '#1e293b' }}>
-                                        {source}
+                                    <option key={source.id} value={source.id} style={{ background: '#1e293b' }}>
+                                        {source.displayName} ({source.jobCount.toLocaleString()})
                                     </option>
                                 ))}
                             </select>
@@ -1,15 +1,19 @@
-import { useEffect, useCallback } from 'react';
+import { useEffect, useCallback, useState } from 'react';
 import { useAppDispatch, useAppSelector } from '@/hooks';
 import { fetchJobs, saveJob, unsaveJob, setFilters } from '@/store/jobsSlice';
 import { JobCard, SearchFilters, LoadingSkeleton } from '@/components';
-import type { JobFilters } from '@/types';
+import { jobsService } from '@/services/jobsService';
+import type { JobFilters, JobSource } from '@/types';
 import { Briefcase, ChevronLeft, ChevronRight } from 'lucide-react';
 
-const sources = ['RemoteOK', 'WeWorkRemotely', 'Remotive', 'Lever'];
-
 export const JobsPage = () => {
     const dispatch = useAppDispatch();
     const { jobs, savedJobs, filters, pagination, isLoading } = useAppSelector((state) => state.jobs);
+    const [sources, setSources] = useState<JobSource[]>([]);
+
+    useEffect(() => {
+        jobsService.getSources().then(setSources).catch(() => setSources([]));
+    }, []);
 
     useEffect(() => {
         dispatch(fetchJobs(filters));
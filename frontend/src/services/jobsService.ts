@@ -1,5 +1,5 @@
 import api from './api';
-import type { Job, JobFilters, JobsResponse } from '@/types';
+import type { Job, JobFilters, JobSource, JobsResponse } from '@/types';
 
 export const jobsService = {
     async getJobs(filters?: JobFilters): Promise<JobsResponse> {
@@ -44,8 +44,8 @@ export const jobsService = {
         await api.post(`/jobs/${jobId}/apply`);
     },
 
-    async getSources(): Promise<string[]> {
-        const response = await api.get<string[]>('/jobs/sources');
+    async getSources(): Promise<JobSource[]> {
+        const response = await api.get<JobSource[]>('/jobs/sources');
         return response.data;
     },
 };
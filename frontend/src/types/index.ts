@@ -32,6 +32,9 @@ export interface Job {
     postedAt: string;
     aiScore?: number;
     matchReason?: string;
+    // Set on saved jobs whose salary changed after they were saved
+    salaryChanged?: boolean;
+    previousSalary?: string;
 }
 
 export interface JobFilters {
@@ -45,6 +48,13 @@ export interface JobFilters {
     limit?: number;
 }
 
+export interface JobSource {
+    id: string;
+    displayName: string;
+    logoUrl?: string;
+    jobCount: number;
+}
+
 export interface JobsResponse {
     jobs: Job[];
     total: number;
@@ -127,10 +137,5 @@ export interface AdminStats {
     totalUsers: number;
     totalJobs: number;
     totalInteractions: number;
-    aiUsage: {
-        date: string;
-        requests: number;
-        tokens: number;
-    }[];
     recentScrapes: ScrapeLog[];
 }